@@ -1,11 +1,12 @@
 package gocd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-
-	multierror "github.com/hashicorp/go-multierror"
+	"net/http"
+	"net/url"
 )
 
 // Agent Object
@@ -23,11 +24,18 @@ type Agent struct {
 		PipelineName string `json:"pipeline_name,omitempty"`
 		StageName    string `json:"stage_name,omitempty"`
 		JobName      string `json:"job_name,omitempty"`
+		// Links points to the "pipeline", "stage" and "job" being built
+		Links map[string]LinkInJSON `json:"_links,omitempty"`
 	} `json:"build_details,omitempty"`
 	Resources []string `json:"resources,omitempty"`
 	Env       []string `json:"environments,omitempty"`
 }
 
+// LinkInJSON - {"href": "..."} object
+type LinkInJSON struct {
+	Href string `json:"href"`
+}
+
 // FreeSpace is required for GoCD API inconsistencies in agent free space scrape.
 type FreeSpace int
 
@@ -54,94 +62,84 @@ func (i *FreeSpace) UnmarshalJSON(data []byte) error {
 
 // GetAllAgents - Lists all available agents, these are agents that are present in the <agents/> tag inside cruise-config.xml and also agents that are in Pending state awaiting registration.
 func (c *DefaultClient) GetAllAgents() ([]*Agent, error) {
-	var errors *multierror.Error
-
-	_, body, errs := c.Request.
-		Get(c.resolve("/go/api/agents")).
-		Set("Accept", "application/vnd.go.cd.v6+json").
-		End()
-	if errs != nil {
-		errors = multierror.Append(errors, errs...)
-		return []*Agent{}, errors.ErrorOrNil()
-	}
+	return c.GetAllAgentsContext(context.Background())
+}
 
+// GetAllAgentsContext - Same as GetAllAgents, bound to the given context
+func (c *DefaultClient) GetAllAgentsContext(ctx context.Context) ([]*Agent, error) {
 	type EmbeddedObj struct {
 		Agents []*Agent `json:"agents"`
 	}
 	type AllAgentsResponse struct {
 		Embedded EmbeddedObj `json:"_embedded"`
 	}
-	var responseFormat *AllAgentsResponse
+	var responseFormat AllAgentsResponse
 
-	jsonErr := json.Unmarshal([]byte(body), &responseFormat)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
-		return []*Agent{}, errors.ErrorOrNil()
+	headers := c.negotiate(ctx, apiAgents).headers()
+	if err := c.do(ctx, http.MethodGet, "/api/agents", headers, nil, &responseFormat); err != nil {
+		return []*Agent{}, err
 	}
-	return responseFormat.Embedded.Agents, errors.ErrorOrNil()
+	return responseFormat.Embedded.Agents, nil
 }
 
 // GetAgent - Gets an agent by its unique identifier (uuid)
 func (c *DefaultClient) GetAgent(uuid string) (*Agent, error) {
-	var errors *multierror.Error
-
-	_, body, errs := c.Request.
-		Get(c.resolve(fmt.Sprintf("/go/api/agents/%s", uuid))).
-		Set("Accept", "application/vnd.go.cd.v6+json").
-		End()
-	errors = multierror.Append(errors, errs...)
-	if errs != nil {
-		return nil, errors.ErrorOrNil()
-	}
+	return c.GetAgentContext(context.Background(), uuid)
+}
 
+// GetAgentContext - Same as GetAgent, bound to the given context
+func (c *DefaultClient) GetAgentContext(ctx context.Context, uuid string) (*Agent, error) {
 	var agent *Agent
 
-	jsonErr := json.Unmarshal([]byte(body), &agent)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
+	headers := c.negotiate(ctx, apiAgents).headers()
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/agents/%s", url.PathEscape(uuid)), headers, nil, &agent); err != nil {
+		return nil, err
 	}
-	return agent, errors.ErrorOrNil()
+	return agent, nil
 }
 
 // UpdateAgent - Update some attributes of an agent (uuid).
 // Returns the updated agent properties
 func (c *DefaultClient) UpdateAgent(uuid string, agent *Agent) (*Agent, error) {
-	var errors *multierror.Error
-
-	_, body, errs := c.Request.
-		Patch(c.resolve(fmt.Sprintf("/go/api/agents/%s", uuid))).
-		Set("Accept", "application/vnd.go.cd.v6+json").
-		SendStruct(agent).
-		End()
-	multierror.Append(errors, errs...)
-	if errs != nil {
-		return nil, errors.ErrorOrNil()
-	}
+	return c.UpdateAgentContext(context.Background(), uuid, agent)
+}
 
+// UpdateAgentContext - Same as UpdateAgent, bound to the given context
+func (c *DefaultClient) UpdateAgentContext(ctx context.Context, uuid string, agent *Agent) (*Agent, error) {
 	var updatedAgent *Agent
 
-	jsonErr := json.Unmarshal([]byte(body), &updatedAgent)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
+	headers := c.negotiate(ctx, apiAgents).headers()
+	if err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/api/agents/%s", url.PathEscape(uuid)), headers, agent, &updatedAgent); err != nil {
+		return nil, err
 	}
-	return updatedAgent, errors.ErrorOrNil()
+	return updatedAgent, nil
 }
 
 // DisableAgent - Disables an agent using it's UUID
 func (c *DefaultClient) DisableAgent(uuid string) error {
+	return c.DisableAgentContext(context.Background(), uuid)
+}
+
+// DisableAgentContext - Same as DisableAgent, bound to the given context
+func (c *DefaultClient) DisableAgentContext(ctx context.Context, uuid string) error {
 	var agent = &Agent{
 		AgentConfigState: "Disabled",
 	}
-	_, err := c.UpdateAgent(uuid, agent)
+	_, err := c.UpdateAgentContext(ctx, uuid, agent)
 	return err
 }
 
 // EnableAgent - Enables an agent using it's UUID
 func (c *DefaultClient) EnableAgent(uuid string) error {
+	return c.EnableAgentContext(context.Background(), uuid)
+}
+
+// EnableAgentContext - Same as EnableAgent, bound to the given context
+func (c *DefaultClient) EnableAgentContext(ctx context.Context, uuid string) error {
 	var agent = &Agent{
 		AgentConfigState: "Enabled",
 	}
-	_, err := c.UpdateAgent(uuid, agent)
+	_, err := c.UpdateAgentContext(ctx, uuid, agent)
 	return err
 }
 
@@ -149,22 +147,90 @@ func (c *DefaultClient) EnableAgent(uuid string) error {
 // PS: You must first disable an agent and ensure that its status is not Building,
 // before attempting to deleting it.
 func (c *DefaultClient) DeleteAgent(uuid string) error {
-	var errors *multierror.Error
-
-	_, _, errs := c.Request.
-		Delete(c.resolve(fmt.Sprintf("/go/api/agents/%s", uuid))).
-		Set("Accept", "application/vnd.go.cd.v6+json").
-		End()
-	if len(errs) > 0 {
-		errors = multierror.Append(errors, errs...)
+	return c.DeleteAgentContext(context.Background(), uuid)
+}
+
+// DeleteAgentContext - Same as DeleteAgent, bound to the given context
+func (c *DefaultClient) DeleteAgentContext(ctx context.Context, uuid string) error {
+	headers := c.negotiate(ctx, apiAgents).headers()
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/agents/%s", url.PathEscape(uuid)), headers, nil, nil)
+}
+
+// BulkAgentUpdate - The changes to apply to several agents at once with
+// BulkUpdateAgents. The empty fields are left untouched.
+type BulkAgentUpdate struct {
+	AddResources       []string
+	RemoveResources    []string
+	AddEnvironments    []string
+	RemoveEnvironments []string
+	// ConfigState is "Enabled" or "Disabled"
+	ConfigState string
+}
+
+// BulkUpdateAgents - Updates the resources, environments and/or config state
+// of the given agents in a single request. None of them is updated if any
+// can't be.
+func (c *DefaultClient) BulkUpdateAgents(uuids []string, update BulkAgentUpdate) (*SimpleMessage, error) {
+	return c.BulkUpdateAgentsContext(context.Background(), uuids, update)
+}
+
+// BulkUpdateAgentsContext - Same as BulkUpdateAgents, bound to the given context
+func (c *DefaultClient) BulkUpdateAgentsContext(ctx context.Context, uuids []string, update BulkAgentUpdate) (*SimpleMessage, error) {
+	type changes struct {
+		Add    []string `json:"add"`
+		Remove []string `json:"remove"`
+	}
+	operations := map[string]changes{}
+	if len(update.AddResources) > 0 || len(update.RemoveResources) > 0 {
+		operations["resources"] = changes{nonNil(update.AddResources), nonNil(update.RemoveResources)}
+	}
+	if len(update.AddEnvironments) > 0 || len(update.RemoveEnvironments) > 0 {
+		operations["environments"] = changes{nonNil(update.AddEnvironments), nonNil(update.RemoveEnvironments)}
+	}
+	data := struct {
+		UUIDs       []string           `json:"uuids"`
+		Operations  map[string]changes `json:"operations,omitempty"`
+		ConfigState string             `json:"agent_config_state,omitempty"`
+	}{uuids, operations, update.ConfigState}
+
+	res := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiAgents).headers()
+	if err := c.do(ctx, http.MethodPatch, "/api/agents", headers, data, res); err != nil {
+		return nil, err
 	}
-	return errors.ErrorOrNil()
+	return res, nil
+}
+
+// BulkDeleteAgents - Deletes the given agents in a single request. As with
+// DeleteAgent, they must be disabled and not Building. None of them is deleted
+// if any can't be.
+func (c *DefaultClient) BulkDeleteAgents(uuids []string) (*SimpleMessage, error) {
+	return c.BulkDeleteAgentsContext(context.Background(), uuids)
+}
+
+// BulkDeleteAgentsContext - Same as BulkDeleteAgents, bound to the given context
+func (c *DefaultClient) BulkDeleteAgentsContext(ctx context.Context, uuids []string) (*SimpleMessage, error) {
+	data := struct {
+		UUIDs []string `json:"uuids"`
+	}{uuids}
+
+	res := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiAgents).headers()
+	if err := c.do(ctx, http.MethodDelete, "/api/agents", headers, data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // AgentRunJobHistory - Lists the jobs that have executed on an agent.
 func (c *DefaultClient) AgentRunJobHistory(uuid string, offset int) (*JobRunHistory, error) {
+	return c.AgentRunJobHistoryContext(context.Background(), uuid, offset)
+}
+
+// AgentRunJobHistoryContext - Same as AgentRunJobHistory, bound to the given context
+func (c *DefaultClient) AgentRunJobHistoryContext(ctx context.Context, uuid string, offset int) (*JobRunHistory, error) {
 	res := new(JobRunHistory)
-	headers := map[string]string{"Accept": "application/json"}
-	err := c.getJSON(fmt.Sprintf("/go/api/agents/%s/job_run_history/%d", uuid, offset), headers, res)
+	headers := c.negotiate(ctx, apiAgentJobRunHistory).headers()
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/agents/%s/job_run_history/%d", url.PathEscape(uuid), offset), headers, nil, res)
 	return res, err
 }
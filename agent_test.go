@@ -1,9 +1,11 @@
 package gocd
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -49,17 +51,24 @@ func TestGetAgent(t *testing.T) {
 	assert.Equal(t, []string{"perf", "UAT"}, agent.Env)
 }
 
-func TestUpdateAgent(t *testing.T) {
+func TestGetAgentContextDeadline(t *testing.T) {
 	t.Parallel()
-	requestBodyValidator := func(body string) error {
-		expectedBody := "{\"build_details\":{},\"hostname\":\"agent02.example.com\"}"
-		if body != expectedBody {
-			return fmt.Errorf("Request body (%s) didn't match the expected body (%s)", body, expectedBody)
-		}
-		return nil
-	}
+	client, server := newTestAPIClient("/go/api/agents/uuid", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	agent, err := client.GetAgentContext(ctx, "uuid")
+	assert.Error(t, err)
+	assert.Nil(t, agent)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
 
-	client, server := newTestAPIClient("/go/api/agents/uuid", serveFileAsJSON(t, "PATCH", "test-fixtures/patch_agent.json", 6, requestBodyValidator))
+func TestUpdateAgent(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"build_details":{},"hostname":"agent02.example.com"}`
+	client, server := newTestAPIClient("/go/api/agents/uuid", serveFileAsJSON(t, "PATCH", "test-fixtures/patch_agent.json", 6, jsonBodyValidator(expectedBody)))
 	defer server.Close()
 	var agent = Agent{
 		Hostname: "agent02.example.com",
@@ -141,3 +150,49 @@ func TestFreeSpace(t *testing.T) {
 	}
 
 }
+
+func TestBulkUpdateAgents(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"uuids":["adb9540a-b954-4571-9d9b-2f330739d4da","adb528b2-b954-1234-9d9b-b27ag4h568e1"],"operations":{"resources":{"add":["java"],"remove":["firefox"]}},"agent_config_state":"Disabled"}`
+	client, server := newTestAPIClient("/go/api/agents", serveFileAsJSON(t, "PATCH", "test-fixtures/bulk_update_agents.json", 6, jsonBodyValidator(expectedBody)))
+	defer server.Close()
+	msg, err := client.BulkUpdateAgents([]string{"adb9540a-b954-4571-9d9b-2f330739d4da", "adb528b2-b954-1234-9d9b-b27ag4h568e1"}, BulkAgentUpdate{
+		AddResources:    []string{"java"},
+		RemoveResources: []string{"firefox"},
+		ConfigState:     "Disabled",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, msg.Message, "Updated agent(s)")
+}
+
+func TestBulkUpdateAgentsEnvironments(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"uuids":["adb9540a-b954-4571-9d9b-2f330739d4da"],"operations":{"environments":{"add":[],"remove":["staging"]}}}`
+	client, server := newTestAPIClient("/go/api/agents", serveFileAsJSON(t, "PATCH", "test-fixtures/bulk_update_agents.json", 6, jsonBodyValidator(expectedBody)))
+	defer server.Close()
+	_, err := client.BulkUpdateAgents([]string{"adb9540a-b954-4571-9d9b-2f330739d4da"}, BulkAgentUpdate{RemoveEnvironments: []string{"staging"}})
+	assert.NoError(t, err)
+}
+
+func TestBulkDeleteAgents(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"uuids":["adb9540a-b954-4571-9d9b-2f330739d4da","adb528b2-b954-1234-9d9b-b27ag4h568e1"]}`
+	client, server := newTestAPIClient("/go/api/agents", serveFileAsJSON(t, "DELETE", "test-fixtures/bulk_delete_agents.json", 6, jsonBodyValidator(expectedBody)))
+	defer server.Close()
+	msg, err := client.BulkDeleteAgents([]string{"adb9540a-b954-4571-9d9b-2f330739d4da", "adb528b2-b954-1234-9d9b-b27ag4h568e1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Deleted 2 agent(s).", msg.Message)
+}
+
+func TestBulkDeleteAgentsFailure(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/agents", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Failed to delete agent(s) with uuid(s): [adb9540a-b954-4571-9d9b-2f330739d4da]. Agents must be disabled before deleting them."}`))
+	})
+	defer server.Close()
+	msg, err := client.BulkDeleteAgents([]string{"adb9540a-b954-4571-9d9b-2f330739d4da"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be disabled")
+	assert.Nil(t, msg)
+}
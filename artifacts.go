@@ -0,0 +1,212 @@
+package gocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Types of the entries of an artifact tree
+const (
+	ArtifactFile   = "file"
+	ArtifactFolder = "folder"
+)
+
+// Artifact is a file or a folder published by a job
+type Artifact struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Type is ArtifactFile or ArtifactFolder
+	Type string `json:"type"`
+	// Files lists the content of a folder
+	Files []*Artifact `json:"files,omitempty"`
+
+	// Path is the path of the artifact relative to the artifacts of the job,
+	// e.g. "target/app.jar", as expected by DownloadArtifact
+	Path string `json:"-"`
+}
+
+// IsFolder reports whether the artifact is a folder
+func (a *Artifact) IsFolder() bool {
+	return a.Type == ArtifactFolder
+}
+
+// artifactZipRetryInterval is how long to wait for GoCD to zip a folder, when
+// it doesn't tell, up to artifactZipMaxRetryInterval when it does. The zip is
+// polled artifactZipMaxPolls times at most, i.e. for about 5 minutes.
+const (
+	artifactZipRetryInterval    = time.Second
+	artifactZipMaxRetryInterval = 10 * time.Second
+	artifactZipMaxPolls         = 300
+)
+
+// ErrArtifactZipTimeout is returned by DownloadArtifactFolder when GoCD is
+// still zipping the folder after all the polls, e.g. because the zip failed
+var ErrArtifactZipTimeout = errors.New("gocd: timed out waiting for the artifact folder to be zipped")
+
+// ListJobArtifacts lists the artifacts published by the given run of a job, as
+// a tree of files and folders
+func (c *DefaultClient) ListJobArtifacts(pipeline string, pipelineCounter int, stage string, stageCounter int, job string) ([]*Artifact, error) {
+	return c.ListJobArtifactsContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, job)
+}
+
+// ListJobArtifactsContext is the same as ListJobArtifacts, bound to the given context
+func (c *DefaultClient) ListJobArtifactsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) ([]*Artifact, error) {
+	artifacts := []*Artifact{}
+	resource := artifactResource(pipeline, pipelineCounter, stage, stageCounter, job, "") + ".json"
+	headers := c.negotiate(ctx, apiArtifacts).headers()
+	if err := c.do(ctx, http.MethodGet, resource, headers, nil, &artifacts); err != nil {
+		return []*Artifact{}, err
+	}
+	setArtifactPaths(artifacts, "")
+	return artifacts, nil
+}
+
+func setArtifactPaths(artifacts []*Artifact, parent string) {
+	for _, a := range artifacts {
+		a.Path = path.Join(parent, a.Name)
+		setArtifactPaths(a.Files, a.Path)
+	}
+}
+
+// DownloadArtifact streams the content of the given artifact file of a job.
+// The caller must close the returned reader, which holds one of the slots of
+// WithMaxConcurrentRequests until then.
+func (c *DefaultClient) DownloadArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error) {
+	return c.DownloadArtifactContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+}
+
+// DownloadArtifactContext is the same as DownloadArtifact, bound to the given context
+func (c *DefaultClient) DownloadArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error) {
+	resource := artifactResource(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	headers := c.negotiate(ctx, apiArtifacts).headers()
+	resp, err := c.stream(ctx, http.MethodGet, resource, headers, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// DownloadArtifactFolder streams the given artifact folder of a job as a zip
+// archive. GoCD zips the folder on the first request, which is waited for, up
+// to a few minutes (see ErrArtifactZipTimeout). The caller must close the
+// returned reader, as soon as possible: see DownloadArtifact.
+func (c *DefaultClient) DownloadArtifactFolder(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error) {
+	return c.DownloadArtifactFolderContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+}
+
+// DownloadArtifactFolderContext is the same as DownloadArtifactFolder, bound to the given context
+func (c *DefaultClient) DownloadArtifactFolderContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error) {
+	resource := artifactResource(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath) + ".zip"
+	headers := c.negotiate(ctx, apiArtifacts).headers()
+	for poll := 1; ; poll++ {
+		resp, err := c.stream(ctx, http.MethodGet, resource, headers, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusAccepted {
+			return resp.Body, nil
+		}
+
+		// the zip is being created
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if poll >= artifactZipMaxPolls {
+			return nil, ErrArtifactZipTimeout
+		}
+		wait, ok := retryAfter(resp.Header.Get("Retry-After"))
+		if !ok {
+			wait = artifactZipRetryInterval
+		}
+		if wait > artifactZipMaxRetryInterval {
+			wait = artifactZipMaxRetryInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// UploadArtifact creates an artifact file of a job with the given content,
+// which is streamed to GoCD. GoCD refuses to overwrite an existing file, see
+// AppendArtifact.
+func (c *DefaultClient) UploadArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error) {
+	return c.UploadArtifactContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+}
+
+// UploadArtifactContext is the same as UploadArtifact, bound to the given context
+func (c *DefaultClient) UploadArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error) {
+	body, writer := io.Pipe()
+	// unblocks the writing goroutine should the request fail early
+	defer body.Close()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("file", path.Base(artifactPath))
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	resource := artifactResource(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	headers := withHeader(c.negotiate(ctx, apiArtifacts).headers(), "Content-Type", form.FormDataContentType())
+	return c.sendArtifact(ctx, http.MethodPost, resource, headers, body)
+}
+
+// AppendArtifact appends the given content, which is streamed to GoCD, to an
+// artifact file of a job. The file is created if it doesn't exist.
+func (c *DefaultClient) AppendArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error) {
+	return c.AppendArtifactContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+}
+
+// AppendArtifactContext is the same as AppendArtifact, bound to the given context
+func (c *DefaultClient) AppendArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error) {
+	resource := artifactResource(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	headers := withHeader(c.negotiate(ctx, apiArtifacts).headers(), "Content-Type", "application/octet-stream")
+	return c.sendArtifact(ctx, http.MethodPut, resource, headers, content)
+}
+
+// sendArtifact streams the content of an artifact to GoCD, which answers with
+// a plain text message
+func (c *DefaultClient) sendArtifact(ctx context.Context, method, resource string, headers map[string]string, content io.Reader) (*SimpleMessage, error) {
+	resp, err := c.stream(ctx, method, resource, headers, content)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	answer, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseMessage(answer), nil
+}
+
+// artifactResource returns the resource of the given artifact of a job, or of
+// the artifacts of the job when artifactPath is empty
+func artifactResource(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) string {
+	resource := fmt.Sprintf("/files/%s/%d/%s/%d/%s", url.PathEscape(pipeline), pipelineCounter, url.PathEscape(stage), stageCounter, url.PathEscape(job))
+	for _, segment := range strings.Split(strings.Trim(artifactPath, "/"), "/") {
+		if segment != "" {
+			resource += "/" + url.PathEscape(segment)
+		}
+	}
+	return resource
+}
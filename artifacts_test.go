@@ -0,0 +1,188 @@
+package gocd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListJobArtifacts(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1.json", serveFileAsJSON(t, "GET", "test-fixtures/list_job_artifacts.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+	artifacts, err := client.ListJobArtifacts("pipeline1", 1, "stage1", 1, "job1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(artifacts))
+
+	output := artifacts[0]
+	assert.Equal(t, "cruise-output", output.Name)
+	assert.True(t, output.IsFolder())
+	assert.Equal(t, 2, len(output.Files))
+	assert.Equal(t, "cruise-output/console.log", output.Files[0].Path)
+
+	jar := artifacts[1].Files[0].Files[0]
+	assert.Equal(t, "app.jar", jar.Name)
+	assert.Equal(t, ArtifactFile, jar.Type)
+	assert.Equal(t, "target/libs/app.jar", jar.Path)
+	assert.Equal(t, "https://ci.example.com/go/files/pipeline1/1/stage1/1/job1/target/libs/app.jar", jar.URL)
+
+	report := artifacts[2]
+	assert.False(t, report.IsFolder())
+	assert.Equal(t, "report.html", report.Path)
+	assert.Empty(t, report.Files)
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/target/libs/", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "GET")
+		BasicAuthCheck(t, r)
+		assert.Equal(t, "/go/files/pipeline1/1/stage1/1/job1/target/libs/my%20app.jar", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/java-archive")
+		w.Write([]byte("PK\x03\x04jar content"))
+	})
+	defer server.Close()
+	content, err := client.DownloadArtifact("pipeline1", 1, "stage1", 1, "job1", "target/libs/my app.jar")
+	assert.NoError(t, err)
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "PK\x03\x04jar content", string(data))
+}
+
+func TestDownloadArtifactOutlivesTimeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == testVersionRoute {
+			serveTestVersion(w, r)
+			return
+		}
+		// the answer comes right away, its body slowly
+		for i := 0; i < 3; i++ {
+			w.Write([]byte("chunk\n"))
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithTimeout(100*time.Millisecond))
+	content, err := client.DownloadArtifact("pipeline1", 1, "stage1", 1, "job1", "slow.txt")
+	assert.NoError(t, err)
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "chunk\nchunk\nchunk\n", string(data))
+
+	// the other requests are still bounded by the timeout
+	_, err = client.GetPipelineStatus("pipeline1")
+	assert.Error(t, err)
+}
+
+func TestDownloadArtifactNotFound(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/missing.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Artifact 'missing.txt' is unavailable as it may have been purged by Go or deleted externally."}`))
+	})
+	defer server.Close()
+	content, err := client.DownloadArtifact("pipeline1", 1, "stage1", 1, "job1", "missing.txt")
+	assert.True(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "purged")
+	assert.Nil(t, content)
+}
+
+func TestDownloadArtifactFolder(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/target.zip", func(w http.ResponseWriter, r *http.Request) {
+		// GoCD answers with 202 Accepted while it zips the folder
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte("Artifacts at this location are being compressed. Please try again later."))
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write([]byte("PK\x03\x04zip content"))
+	})
+	defer server.Close()
+	content, err := client.DownloadArtifactFolder("pipeline1", 1, "stage1", 1, "job1", "target")
+	assert.NoError(t, err)
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "PK\x03\x04zip content", string(data))
+	assert.Equal(t, int32(2), requests)
+}
+
+func TestDownloadArtifactFolderNeverZipped(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/target.zip", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer server.Close()
+	content, err := client.DownloadArtifactFolder("pipeline1", 1, "stage1", 1, "job1", "target")
+	assert.Equal(t, ErrArtifactZipTimeout, err)
+	assert.Nil(t, content)
+	assert.Equal(t, int32(artifactZipMaxPolls), requests)
+}
+
+func TestUploadArtifact(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/reports/summary.txt", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "POST")
+		assert.Equal(t, "true", r.Header.Get("X-GoCD-Confirm"))
+		file, header, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		assert.Equal(t, "summary.txt", header.Filename)
+		data, _ := ioutil.ReadAll(file)
+		assert.Equal(t, "all tests passed\n", string(data))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("File reports/summary.txt was created successfully"))
+	})
+	defer server.Close()
+	msg, err := client.UploadArtifact("pipeline1", 1, "stage1", 1, "job1", "reports/summary.txt", strings.NewReader("all tests passed\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "File reports/summary.txt was created successfully", msg.Message)
+}
+
+func TestUploadArtifactAlreadyExists(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/summary.txt", func(w http.ResponseWriter, r *http.Request) {
+		// don't read the upload
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "Cannot overwrite existing file"}`))
+	})
+	defer server.Close()
+	_, err := client.UploadArtifact("pipeline1", 1, "stage1", 1, "job1", "summary.txt", strings.NewReader(strings.Repeat("x", 1<<20)))
+	assert.True(t, IsForbidden(err))
+}
+
+func TestAppendArtifact(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/logs/deploy.log", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "PUT")
+		assert.Equal(t, "true", r.Header.Get("X-GoCD-Confirm"))
+		data, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "deployed\n", string(data))
+		w.Write([]byte("File logs/deploy.log was appended successfully"))
+	})
+	defer server.Close()
+	msg, err := client.AppendArtifact("pipeline1", 1, "stage1", 1, "job1", "logs/deploy.log", strings.NewReader("deployed\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "File logs/deploy.log was appended successfully", msg.Message)
+}
@@ -1,35 +1,178 @@
 package gocd
 
+import (
+	"context"
+	"io"
+)
+
 // Client interface that exposes all the API methods supported by the underlying Client
+//
+// Every method has a Context variant (e.g. GetAllAgentsContext) which binds the
+// underlying HTTP calls to the given context, so they are aborted as soon as
+// it's cancelled or its deadline expires. The methods without a context use
+// context.Background(). The iterators over the paginated histories (e.g.
+// IteratePipelineHistory) get a context for every call to their Next method,
+// and WaitForPipeline, TailJobConsoleLog, DrainAgent and DecommissionAgents,
+// which may poll GoCD for long, always take one.
 type Client interface {
 	// Agents API
 	GetAllAgents() ([]*Agent, error)
+	GetAllAgentsContext(ctx context.Context) ([]*Agent, error)
 	GetAgent(uuid string) (*Agent, error)
+	GetAgentContext(ctx context.Context, uuid string) (*Agent, error)
 	UpdateAgent(uuid string, agent *Agent) (*Agent, error)
+	UpdateAgentContext(ctx context.Context, uuid string, agent *Agent) (*Agent, error)
 	DisableAgent(uuid string) error
+	DisableAgentContext(ctx context.Context, uuid string) error
 	EnableAgent(uuid string) error
+	EnableAgentContext(ctx context.Context, uuid string) error
 	DeleteAgent(uuid string) error
+	DeleteAgentContext(ctx context.Context, uuid string) error
+	BulkUpdateAgents(uuids []string, update BulkAgentUpdate) (*SimpleMessage, error)
+	BulkUpdateAgentsContext(ctx context.Context, uuids []string, update BulkAgentUpdate) (*SimpleMessage, error)
+	BulkDeleteAgents(uuids []string) (*SimpleMessage, error)
+	BulkDeleteAgentsContext(ctx context.Context, uuids []string) (*SimpleMessage, error)
 	AgentRunJobHistory(uuid string, offset int) (*JobRunHistory, error)
+	AgentRunJobHistoryContext(ctx context.Context, uuid string, offset int) (*JobRunHistory, error)
+	IterateAgentRunJobHistory(uuid string, bounds HistoryBounds) *JobHistoryIterator
+	DrainAgent(ctx context.Context, uuid string, opts DrainOptions) (*Agent, error)
+	DecommissionAgents(ctx context.Context, uuids []string, opts DrainOptions) ([]AgentOutcome, error)
+
+	// Users API
+	GetAllUsers() ([]*User, error)
+	GetAllUsersContext(ctx context.Context) ([]*User, error)
+	GetUser(loginName string) (*User, error)
+	GetUserContext(ctx context.Context, loginName string) (*User, error)
+	CreateUser(user *User) (*User, error)
+	CreateUserContext(ctx context.Context, user *User) (*User, error)
+	UpdateUser(loginName string, update UserUpdate) (*User, error)
+	UpdateUserContext(ctx context.Context, loginName string, update UserUpdate) (*User, error)
+	DeleteUsers(loginNames []string) error
+	DeleteUsersContext(ctx context.Context, loginNames []string) error
+
+	// Materials API
+	GetAllMaterials() ([]*Material, error)
+	GetAllMaterialsContext(ctx context.Context) ([]*Material, error)
+	GetMaterialModifications(fingerprint string, offset int) (*MaterialModificationsPage, error)
+	GetMaterialModificationsContext(ctx context.Context, fingerprint string, offset int) (*MaterialModificationsPage, error)
+	NotifyGitMaterial(repoURL string) (*SimpleMessage, error)
+	NotifyGitMaterialContext(ctx context.Context, repoURL string) (*SimpleMessage, error)
+	NotifySVNMaterial(uuid string) (*SimpleMessage, error)
+	NotifySVNMaterialContext(ctx context.Context, uuid string) (*SimpleMessage, error)
+	NotifyHgMaterial(repoURL string) (*SimpleMessage, error)
+	NotifyHgMaterialContext(ctx context.Context, repoURL string) (*SimpleMessage, error)
 
 	// Pipeline Groups API
 	GetPipelineGroups() ([]*PipelineGroup, error)
+	GetPipelineGroupsContext(ctx context.Context) ([]*PipelineGroup, error)
+	GetAllPipelineGroupConfigs() ([]*PipelineGroupConfig, error)
+	GetAllPipelineGroupConfigsContext(ctx context.Context) ([]*PipelineGroupConfig, error)
+	GetPipelineGroupConfig(name string) (*PipelineGroupConfig, error)
+	GetPipelineGroupConfigContext(ctx context.Context, name string) (*PipelineGroupConfig, error)
+	CreatePipelineGroupConfig(group *PipelineGroupConfig) (*PipelineGroupConfig, error)
+	CreatePipelineGroupConfigContext(ctx context.Context, group *PipelineGroupConfig) (*PipelineGroupConfig, error)
+	UpdatePipelineGroupConfig(group *PipelineGroupConfig) (*PipelineGroupConfig, error)
+	UpdatePipelineGroupConfigContext(ctx context.Context, group *PipelineGroupConfig) (*PipelineGroupConfig, error)
+	DeletePipelineGroupConfig(name string) error
+	DeletePipelineGroupConfigContext(ctx context.Context, name string) error
 
 	// Pipelines API
 	GetPipelineInstance(string, int) (*PipelineInstance, error)
+	GetPipelineInstanceContext(context.Context, string, int) (*PipelineInstance, error)
 	GetPipelineHistoryPage(string, int) (*PipelineHistoryPage, error)
+	GetPipelineHistoryPageContext(context.Context, string, int) (*PipelineHistoryPage, error)
+	IteratePipelineHistory(name string, bounds HistoryBounds) *PipelineHistoryIterator
 	GetPipelineStatus(string) (*PipelineStatus, error)
+	GetPipelineStatusContext(context.Context, string) (*PipelineStatus, error)
 	PausePipeline(string, string) (*SimpleMessage, error)
+	PausePipelineContext(context.Context, string, string) (*SimpleMessage, error)
 	UnpausePipeline(string) (*SimpleMessage, error)
+	UnpausePipelineContext(context.Context, string) (*SimpleMessage, error)
 	UnlockPipeline(string) (*SimpleMessage, error)
+	UnlockPipelineContext(context.Context, string) (*SimpleMessage, error)
+	SchedulePipeline(name string, opts ScheduleOptions) (*ScheduleResult, error)
+	SchedulePipelineContext(ctx context.Context, name string, opts ScheduleOptions) (*ScheduleResult, error)
+	GetScheduledPipelineInstance(result *ScheduleResult) (*PipelineInstance, error)
+	GetScheduledPipelineInstanceContext(ctx context.Context, result *ScheduleResult) (*PipelineInstance, error)
+	WaitForPipeline(ctx context.Context, name string, counter int, opts WaitOptions) (*PipelineResult, error)
+
+	// Pipeline Config API
+	GetPipelineConfig(name string) (*PipelineConfig, error)
+	GetPipelineConfigContext(ctx context.Context, name string) (*PipelineConfig, error)
+	CreatePipelineConfig(group string, config *PipelineConfig) (*PipelineConfig, error)
+	CreatePipelineConfigContext(ctx context.Context, group string, config *PipelineConfig) (*PipelineConfig, error)
+	UpdatePipelineConfig(config *PipelineConfig) (*PipelineConfig, error)
+	UpdatePipelineConfigContext(ctx context.Context, config *PipelineConfig) (*PipelineConfig, error)
+	DeletePipelineConfig(name string) error
+	DeletePipelineConfigContext(ctx context.Context, name string) error
+
+	// Templates API
+	GetAllTemplates() ([]*TemplateConfig, error)
+	GetAllTemplatesContext(ctx context.Context) ([]*TemplateConfig, error)
+	GetTemplate(name string) (*TemplateConfig, error)
+	GetTemplateContext(ctx context.Context, name string) (*TemplateConfig, error)
+	CreateTemplate(template *TemplateConfig) (*TemplateConfig, error)
+	CreateTemplateContext(ctx context.Context, template *TemplateConfig) (*TemplateConfig, error)
+	UpdateTemplate(template *TemplateConfig) (*TemplateConfig, error)
+	UpdateTemplateContext(ctx context.Context, template *TemplateConfig) (*TemplateConfig, error)
+	DeleteTemplate(name string) error
+	DeleteTemplateContext(ctx context.Context, name string) error
+
+	// Stages API
+	RunStage(pipeline string, pipelineCounter int, stage string) (*SimpleMessage, error)
+	RunStageContext(ctx context.Context, pipeline string, pipelineCounter int, stage string) (*SimpleMessage, error)
+	CancelStage(pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error)
+	CancelStageContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error)
+	RerunFailedJobs(pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error)
+	RerunFailedJobsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error)
+	RerunSelectedJobs(pipeline string, pipelineCounter int, stage string, stageCounter int, jobs []string) (*SimpleMessage, error)
+	RerunSelectedJobsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, jobs []string) (*SimpleMessage, error)
+	GetStageInstance(pipeline string, pipelineCounter int, stage string, stageCounter int) (*StageInstance, error)
+	GetStageInstanceContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*StageInstance, error)
+	GetStageHistory(pipeline, stage string, offset int) (*StageHistoryPage, error)
+	GetStageHistoryContext(ctx context.Context, pipeline, stage string, offset int) (*StageHistoryPage, error)
 
 	// Jobs API
 	GetScheduledJobs() ([]*ScheduledJob, error)
+	GetScheduledJobsContext(ctx context.Context) ([]*ScheduledJob, error)
 	GetJobHistory(pipeline, stage, job string, offset int) ([]*JobHistory, error)
+	GetJobHistoryContext(ctx context.Context, pipeline, stage, job string, offset int) ([]*JobHistory, error)
+	IterateJobHistory(pipeline, stage, job string, bounds HistoryBounds) *JobHistoryIterator
+	GetJobConsoleLog(pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (io.ReadCloser, error)
+	GetJobConsoleLogContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (io.ReadCloser, error)
+	TailJobConsoleLog(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, startLine int) *ConsoleLogTail
+
+	// Artifacts API
+	ListJobArtifacts(pipeline string, pipelineCounter int, stage string, stageCounter int, job string) ([]*Artifact, error)
+	ListJobArtifactsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) ([]*Artifact, error)
+	DownloadArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error)
+	DownloadArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error)
+	DownloadArtifactFolder(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error)
+	DownloadArtifactFolderContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string) (io.ReadCloser, error)
+	UploadArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error)
+	UploadArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error)
+	AppendArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error)
+	AppendArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job, artifactPath string, content io.Reader) (*SimpleMessage, error)
 
 	// Environment Config API
 	GetAllEnvironmentConfigs() ([]*EnvironmentConfig, error)
+	GetAllEnvironmentConfigsContext(ctx context.Context) ([]*EnvironmentConfig, error)
 	GetEnvironmentConfig(name string) (*EnvironmentConfig, error)
+	GetEnvironmentConfigContext(ctx context.Context, name string) (*EnvironmentConfig, error)
+	CreateEnvironmentConfig(environment *EnvironmentConfig) (*EnvironmentConfig, error)
+	CreateEnvironmentConfigContext(ctx context.Context, environment *EnvironmentConfig) (*EnvironmentConfig, error)
+	UpdateEnvironmentConfig(environment *EnvironmentConfig) (*EnvironmentConfig, error)
+	UpdateEnvironmentConfigContext(ctx context.Context, environment *EnvironmentConfig) (*EnvironmentConfig, error)
+	PatchEnvironmentConfig(name string, patch EnvironmentConfigPatch) (*EnvironmentConfig, error)
+	PatchEnvironmentConfigContext(ctx context.Context, name string, patch EnvironmentConfigPatch) (*EnvironmentConfig, error)
+	DeleteEnvironmentConfig(name string) error
+	DeleteEnvironmentConfigContext(ctx context.Context, name string) error
 
 	// Server health
 	GetServerHealthMessages() ([]*ServerHealthMessage, error)
+	GetServerHealthMessagesContext(ctx context.Context) ([]*ServerHealthMessage, error)
+
+	// Server version
+	GetServerVersion() (*ServerVersion, error)
+	GetServerVersionContext(ctx context.Context) (*ServerVersion, error)
 }
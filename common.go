@@ -1,5 +1,10 @@
 package gocd
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 // Pagination is a structure used in several places when the gocd api paginates
 // the results. In the history of jobs and pipelines for example
 type Pagination struct {
@@ -13,3 +18,34 @@ type Pagination struct {
 type SimpleMessage struct {
 	Message string `json:"message"`
 }
+
+// parseMessage reads the message of a JSON SimpleMessage, or of a plain text
+// answer
+func parseMessage(body []byte) *SimpleMessage {
+	msg := new(SimpleMessage)
+	if err := json.Unmarshal(body, msg); err != nil {
+		msg.Message = strings.TrimSpace(string(body))
+	}
+	return msg
+}
+
+// Bool returns a pointer to the given value, to fill in the optional fields
+// such as UserUpdate.Enabled
+func Bool(v bool) *bool {
+	return &v
+}
+
+// String returns a pointer to the given value, to fill in the optional fields
+// such as UserUpdate.Email
+func String(v string) *string {
+	return &v
+}
+
+// nonNil returns the given values, or an empty slice instead of nil so that it
+// is sent as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,237 @@
+package gocd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// consoleLogPath is where GoCD stores the console log of a job, among its
+// artifacts
+const consoleLogPath = "cruise-output/console.log"
+
+// consoleLogPollInterval is the delay between the polls of a console log which
+// didn't grow, consoleLogBusyPollInterval the one between the polls of a log
+// being written
+const (
+	consoleLogPollInterval     = 2 * time.Second
+	consoleLogBusyPollInterval = 500 * time.Millisecond
+)
+
+// ConsoleLine is a line of the console log of a job
+type ConsoleLine struct {
+	// Number is the number of the line in the log, counting from 1
+	Number int
+	Text   string
+}
+
+// ConsoleLogTail follows the console log of a job, see TailJobConsoleLog
+type ConsoleLogTail struct {
+	// Lines receives the lines of the log. It's closed once the job
+	// completed and all its log was read, when the tail fails or is stopped.
+	Lines  <-chan ConsoleLine
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Err returns the error which stopped the tail, if any. It must only be called
+// once Lines is closed, it returns nil before.
+func (t *ConsoleLogTail) Err() error {
+	select {
+	case <-t.done:
+		return t.err
+	default:
+		return nil
+	}
+}
+
+// Stop stops the tail, if it's still running, and waits for Lines to be
+// closed. The tail isn't considered failed then: Err returns nil, unless it
+// failed before.
+func (t *ConsoleLogTail) Stop() {
+	t.cancel()
+	<-t.done
+}
+
+// GetJobConsoleLog streams the console log of the given run of a job, as far
+// as it's written. The caller must close the returned reader, as soon as
+// possible: see DownloadArtifact.
+func (c *DefaultClient) GetJobConsoleLog(pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (io.ReadCloser, error) {
+	return c.GetJobConsoleLogContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, job)
+}
+
+// GetJobConsoleLogContext is the same as GetJobConsoleLog, bound to the given context
+func (c *DefaultClient) GetJobConsoleLogContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (io.ReadCloser, error) {
+	return c.DownloadArtifactContext(ctx, pipeline, pipelineCounter, stage, stageCounter, job, consoleLogPath)
+}
+
+// TailJobConsoleLog follows the console log of the given run of a job until
+// the job completes, polling GoCD for what was appended to the log since the
+// last poll. The lines before startLine (counting from 1) are skipped. The
+// job doesn't have to be running yet, but the tail fails with the 404 of GoCD
+// once its stage was scheduled without the job showing up, or if the pipeline
+// run still doesn't exist after a couple of minutes.
+//
+// The tail only stops on its own once the job completes: a caller which stops
+// reading Lines earlier must call Stop or cancel ctx, or the goroutine feeding
+// Lines leaks.
+// usage:
+//
+//	tail := client.TailJobConsoleLog(ctx, "pipeline1", 12, "stage1", 1, "job1", 1)
+//	defer tail.Stop()
+//	for line := range tail.Lines {
+//		fmt.Println(line.Text)
+//	}
+//	if err := tail.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+func (c *DefaultClient) TailJobConsoleLog(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, startLine int) *ConsoleLogTail {
+	lines := make(chan ConsoleLine)
+	tailCtx, cancel := context.WithCancel(ctx)
+	tail := &ConsoleLogTail{Lines: lines, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(tail.done)
+		defer close(lines)
+		defer cancel()
+		err := c.tailJobConsoleLog(tailCtx, pipeline, pipelineCounter, stage, stageCounter, job, startLine, lines)
+		if ctx.Err() == nil && tailCtx.Err() == context.Canceled {
+			// stopped by Stop
+			err = nil
+		}
+		tail.err = err
+	}()
+	return tail
+}
+
+func (c *DefaultClient) tailJobConsoleLog(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, startLine int, lines chan<- ConsoleLine) error {
+	var offset int64
+	var pending []byte
+	number := 0
+	started := time.Now()
+	emit := func(text string) error {
+		if number++; number < startLine {
+			return nil
+		}
+		select {
+		case lines <- ConsoleLine{Number: number, Text: strings.TrimSuffix(text, "\r")}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		// the state is checked first so that nothing written before the job
+		// completed is missed
+		completed, err := c.jobCompleted(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+		if IsNotFound(err) {
+			err = c.stageNotFound(ctx, pipeline, pipelineCounter, stage, err, started)
+		}
+		if err != nil {
+			return err
+		}
+		chunk, err := c.readConsoleLog(ctx, pipeline, pipelineCounter, stage, stageCounter, job, offset)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(chunk))
+		pending = append(pending, chunk...)
+		for {
+			i := bytes.IndexByte(pending, '\n')
+			if i < 0 {
+				break
+			}
+			if err := emit(string(pending[:i])); err != nil {
+				return err
+			}
+			pending = pending[i+1:]
+		}
+
+		if completed {
+			if len(pending) > 0 {
+				return emit(string(pending))
+			}
+			return nil
+		}
+		interval := consoleLogPollInterval
+		if len(chunk) > 0 {
+			// the job is writing, more is likely to come soon
+			interval = consoleLogBusyPollInterval
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// jobCompleted reports whether the given run of a job completed
+func (c *DefaultClient) jobCompleted(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (bool, error) {
+	instance, err := c.GetStageInstanceContext(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	if err != nil {
+		return false, err
+	}
+	for _, j := range instance.Jobs {
+		if j.Name == job {
+			return j.State == "Completed", nil
+		}
+	}
+	return false, fmt.Errorf("gocd: no job %s in the run %d/%d of the stage %s of %s", job, pipelineCounter, stageCounter, stage, pipeline)
+}
+
+// stageNotFound tells a stage which isn't scheduled yet, for which nil is
+// returned, from one which should already exist, for which notFound is. The
+// pipeline run itself may not exist for defaultNotFoundTimeout since the tail
+// started.
+func (c *DefaultClient) stageNotFound(ctx context.Context, pipeline string, pipelineCounter int, stage string, notFound error, started time.Time) error {
+	instance, err := c.GetPipelineInstanceContext(ctx, pipeline, pipelineCounter)
+	if IsNotFound(err) && time.Since(started) < defaultNotFoundTimeout {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, s := range instance.Stages {
+		if s.Name == stage {
+			if s.Scheduled {
+				return notFound
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("gocd: no stage %s in the run %d of %s", stage, pipelineCounter, pipeline)
+}
+
+// readConsoleLog returns what was written to the console log of the job
+// after the given offset, nothing if the log doesn't exist yet
+func (c *DefaultClient) readConsoleLog(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, offset int64) ([]byte, error) {
+	resource := artifactResource(pipeline, pipelineCounter, stage, stageCounter, job, consoleLogPath)
+	headers := c.negotiate(ctx, apiArtifacts).headers()
+	if offset > 0 {
+		headers = withHeader(headers, "Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := c.stream(ctx, http.MethodGet, resource, headers, nil)
+	if IsNotFound(err) || hasStatus(err, http.StatusRequestedRangeNotSatisfiable) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent && offset > 0 {
+		// the whole log was sent
+		if _, err := io.CopyN(ioutil.Discard, resp.Body, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,180 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestConsoleServer serves a job whose console log is the given content,
+// growing at every poll of the job state. The job completes with the last
+// content. Range requests are only honored when ranges is true.
+func newTestConsoleServer(t *testing.T, contents []string, ranges bool) (Client, *httptest.Server) {
+	var polls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	mux.HandleFunc("/go/api/stages/pipeline1/stage1/instance/1/1", func(w http.ResponseWriter, r *http.Request) {
+		state := "Building"
+		if int(atomic.AddInt32(&polls, 1)) >= len(contents) {
+			state = "Completed"
+		}
+		json.NewEncoder(w).Encode(StageInstance{StageRun: StageRun{Name: "stage1", Jobs: []Job{{Name: "job1", State: state}}}})
+	})
+	mux.HandleFunc("/go/files/pipeline1/1/stage1/1/job1/cruise-output/console.log", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "GET")
+		content := contents[atomic.LoadInt32(&polls)-1]
+		var start int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err != nil || !ranges {
+			w.Write([]byte(content))
+			return
+		}
+		if start >= len(content) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(content[start:]))
+	})
+	server := httptest.NewServer(mux)
+	return New(server.URL, testUsername, testPassword), server
+}
+
+func collectConsoleLines(t *testing.T, tail *ConsoleLogTail) []ConsoleLine {
+	lines := []ConsoleLine{}
+	for line := range tail.Lines {
+		lines = append(lines, line)
+	}
+	assert.NoError(t, tail.Err())
+	return lines
+}
+
+func TestGetJobConsoleLog(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/files/pipeline1/1/stage1/1/job1/cruise-output/console.log", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "GET")
+		w.Write([]byte("[go] Job started\n[go] Job completed\n"))
+	})
+	defer server.Close()
+	log, err := client.GetJobConsoleLog("pipeline1", 1, "stage1", 1, "job1")
+	assert.NoError(t, err)
+	defer log.Close()
+	content, err := ioutil.ReadAll(log)
+	assert.NoError(t, err)
+	assert.Equal(t, "[go] Job started\n[go] Job completed\n", string(content))
+}
+
+func TestTailJobConsoleLog(t *testing.T) {
+	t.Parallel()
+	client, server := newTestConsoleServer(t, []string{
+		"line1\nline2\npart",
+		"line1\nline2\npartial\r\n",
+		"line1\nline2\npartial\r\nlast line",
+	}, true)
+	defer server.Close()
+
+	start := time.Now()
+	lines := collectConsoleLines(t, client.TailJobConsoleLog(context.Background(), "pipeline1", 1, "stage1", 1, "job1", 0))
+	assert.Equal(t, []ConsoleLine{
+		{Number: 1, Text: "line1"},
+		{Number: 2, Text: "line2"},
+		{Number: 3, Text: "partial"},
+		{Number: 4, Text: "last line"},
+	}, lines)
+	// the log grew at every poll, which still weren't back-to-back
+	assert.True(t, time.Since(start) >= 2*consoleLogBusyPollInterval, "3 polls took %s", time.Since(start))
+}
+
+func TestTailJobConsoleLogStartLine(t *testing.T) {
+	t.Parallel()
+	client, server := newTestConsoleServer(t, []string{"line1\nline2\n", "line1\nline2\nline3\n"}, true)
+	defer server.Close()
+
+	lines := collectConsoleLines(t, client.TailJobConsoleLog(context.Background(), "pipeline1", 1, "stage1", 1, "job1", 2))
+	assert.Equal(t, []ConsoleLine{{Number: 2, Text: "line2"}, {Number: 3, Text: "line3"}}, lines)
+}
+
+func TestTailJobConsoleLogWithoutRanges(t *testing.T) {
+	t.Parallel()
+	client, server := newTestConsoleServer(t, []string{"line1\n", "line1\nline2\n"}, false)
+	defer server.Close()
+
+	lines := collectConsoleLines(t, client.TailJobConsoleLog(context.Background(), "pipeline1", 1, "stage1", 1, "job1", 0))
+	assert.Equal(t, []ConsoleLine{{Number: 1, Text: "line1"}, {Number: 2, Text: "line2"}}, lines)
+}
+
+// newTestMissingStageServer serves a run #1 of pipeline1 made of the given
+// stages, none of which can be fetched
+func newTestMissingStageServer(stages ...StageRun) (Client, *httptest.Server) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	mux.HandleFunc("/go/api/pipelines/pipeline1/instance/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(PipelineInstance{Name: "pipeline1", Counter: 1, Stages: stages})
+	})
+	mux.HandleFunc("/go/api/stages/pipeline1/stage1/instance/1/1", http.NotFound)
+	server := httptest.NewServer(mux)
+	return New(server.URL, testUsername, testPassword), server
+}
+
+func TestTailJobConsoleLogCancelled(t *testing.T) {
+	t.Parallel()
+	// the stage isn't scheduled yet
+	client, server := newTestMissingStageServer(StageRun{Name: "stage1", Counter: "1"})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	tail := client.TailJobConsoleLog(ctx, "pipeline1", 1, "stage1", 1, "job1", 0)
+	for range tail.Lines {
+		t.Error("unexpected line")
+	}
+	assert.True(t, errors.Is(tail.Err(), context.DeadlineExceeded))
+}
+
+func TestTailJobConsoleLogStop(t *testing.T) {
+	t.Parallel()
+	client, server := newTestMissingStageServer(StageRun{Name: "stage1", Counter: "1"})
+	defer server.Close()
+
+	tail := client.TailJobConsoleLog(context.Background(), "pipeline1", 1, "stage1", 1, "job1", 0)
+	assert.NoError(t, tail.Err())
+	tail.Stop()
+	_, open := <-tail.Lines
+	assert.False(t, open)
+	assert.NoError(t, tail.Err())
+	// stopping it again is harmless
+	tail.Stop()
+}
+
+func TestTailJobConsoleLogNotFound(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the stage was scheduled, so GoCD should know its run
+	client, server := newTestMissingStageServer(StageRun{Name: "stage1", Counter: "1", Scheduled: true})
+	defer server.Close()
+	tail := client.TailJobConsoleLog(ctx, "pipeline1", 1, "stage1", 1, "job1", 0)
+	for range tail.Lines {
+		t.Error("unexpected line")
+	}
+	assert.True(t, IsNotFound(tail.Err()), "unexpected error %v", tail.Err())
+
+	client, server = newTestMissingStageServer(StageRun{Name: "stage2", Counter: "1"})
+	defer server.Close()
+	tail = client.TailJobConsoleLog(ctx, "pipeline1", 1, "stage1", 1, "job1", 0)
+	for range tail.Lines {
+		t.Error("unexpected line")
+	}
+	assert.EqualError(t, tail.Err(), "gocd: no stage stage1 in the run 1 of pipeline1")
+	assert.NoError(t, ctx.Err())
+}
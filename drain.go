@@ -0,0 +1,231 @@
+package gocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Steps of the drain of an agent, as reported to DrainOptions.OnProgress
+const (
+	AgentDisabled       = "Disabled"
+	AgentStageCancelled = "StageCancelled"
+	AgentIdle           = "Idle"
+	AgentDeleted        = "Deleted"
+)
+
+// ErrDrainTimeout is returned by DrainAgent when DrainOptions.Timeout elapses
+// before the agent is idle
+var ErrDrainTimeout = errors.New("gocd: timed out waiting for the agent to be idle")
+
+// DrainOptions customizes how DrainAgent and DecommissionAgents wait for the
+// agents to finish their jobs
+type DrainOptions struct {
+	// PollInterval is the delay between the polls of an agent, 5s when zero
+	PollInterval time.Duration
+	// Timeout bounds the wait for an agent to be idle, when not zero
+	Timeout time.Duration
+	// CancelAfter, when not zero, is how long an agent is given to finish its
+	// job before the stage of the job is cancelled
+	CancelAfter time.Duration
+	// Concurrency is how many agents DecommissionAgents drains at once, 10
+	// when zero
+	Concurrency int
+	// OnProgress, when not nil, is called for every step of the drain of an
+	// agent. DecommissionAgents never calls it concurrently.
+	OnProgress func(AgentProgress)
+}
+
+// AgentProgress is a step of the drain of an agent
+type AgentProgress struct {
+	UUID string
+	// Step is AgentDisabled, AgentStageCancelled, AgentIdle or AgentDeleted
+	Step string
+	// Agent is the last state of the agent seen
+	Agent *Agent
+}
+
+// AgentOutcome is the outcome of the decommission of an agent
+type AgentOutcome struct {
+	UUID string
+	// Agent is the last state of the agent seen, if any
+	Agent *Agent
+	// Deleted tells whether the agent was deleted, Err tells why not otherwise
+	Deleted bool
+	Err     error
+}
+
+// DrainAgent disables the given agent, so that it isn't assigned any new job,
+// and polls it until it's done with its current job, if any. An agent which
+// lost contact with the server is considered idle. The agent is left
+// disabled, see DecommissionAgents to delete it too.
+//
+// When the drain is interrupted by DrainOptions.Timeout (ErrDrainTimeout) or
+// by ctx, the last state of the agent seen, if any, is returned along with the
+// error.
+func (c *DefaultClient) DrainAgent(ctx context.Context, uuid string, opts DrainOptions) (*Agent, error) {
+	return c.drainAgent(ctx, uuid, opts, opts.OnProgress)
+}
+
+// DecommissionAgents drains the given agents (see DrainAgent), up to
+// DrainOptions.Concurrency at once, and deletes each of them as soon as it's
+// idle. The outcomes are listed in the order of the given agents. An error is
+// returned when any of the agents couldn't be deleted, their outcomes tell why.
+//
+// Every agent being drained is polled on its own: consider WithRateLimit to
+// spare the server.
+func (c *DefaultClient) DecommissionAgents(ctx context.Context, uuids []string, opts DrainOptions) ([]AgentOutcome, error) {
+	var mu sync.Mutex
+	progress := func(p AgentProgress) {
+		if opts.OnProgress != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			opts.OnProgress(p)
+		}
+	}
+
+	outcomes := make([]AgentOutcome, len(uuids))
+	decommission := func(outcome *AgentOutcome, uuid string) {
+		outcome.UUID = uuid
+		if outcome.Agent, outcome.Err = c.drainAgent(ctx, uuid, opts, progress); outcome.Err != nil {
+			return
+		}
+		if outcome.Err = c.DeleteAgentContext(ctx, uuid); outcome.Err != nil {
+			return
+		}
+		outcome.Deleted = true
+		progress(AgentProgress{UUID: uuid, Step: AgentDeleted, Agent: outcome.Agent})
+	}
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = 10
+	}
+	if workers > len(uuids) {
+		workers = len(uuids)
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				decommission(&outcomes[i], uuids[i])
+			}
+		}()
+	}
+	for i := range uuids {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	failed := 0
+	for _, outcome := range outcomes {
+		if !outcome.Deleted {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return outcomes, fmt.Errorf("gocd: %d of %d agents couldn't be decommissioned", failed, len(uuids))
+	}
+	return outcomes, nil
+}
+
+func (c *DefaultClient) drainAgent(ctx context.Context, uuid string, opts DrainOptions, onProgress func(AgentProgress)) (*Agent, error) {
+	drainCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		drainCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	progress := func(step string, agent *Agent) {
+		if onProgress != nil {
+			onProgress(AgentProgress{UUID: uuid, Step: step, Agent: agent})
+		}
+	}
+
+	agent, err := c.UpdateAgentContext(drainCtx, uuid, &Agent{AgentConfigState: "Disabled"})
+	if err != nil {
+		return nil, timeoutError(ctx, drainCtx, err, ErrDrainTimeout)
+	}
+	progress(AgentDisabled, agent)
+
+	start := time.Now()
+	cancelled := false
+	for {
+		if agentIdle(agent) {
+			progress(AgentIdle, agent)
+			return agent, nil
+		}
+		if opts.CancelAfter > 0 && !cancelled && time.Since(start) >= opts.CancelAfter {
+			if pipeline, pipelineCounter, stage, stageCounter, ok := agentBuildingStage(agent); ok {
+				if _, err := c.CancelStageContext(drainCtx, pipeline, pipelineCounter, stage, stageCounter); err != nil {
+					return agent, timeoutError(ctx, drainCtx, err, ErrDrainTimeout)
+				}
+				progress(AgentStageCancelled, agent)
+			}
+			cancelled = true
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-drainCtx.Done():
+			timer.Stop()
+			return agent, timeoutError(ctx, drainCtx, drainCtx.Err(), ErrDrainTimeout)
+		case <-timer.C:
+		}
+		polled, err := c.GetAgentContext(drainCtx, uuid)
+		if err != nil {
+			return agent, timeoutError(ctx, drainCtx, err, ErrDrainTimeout)
+		}
+		agent = polled
+	}
+}
+
+// agentIdle reports whether the agent won't run any job anymore
+func agentIdle(agent *Agent) bool {
+	if agent == nil {
+		return false
+	}
+	switch agent.AgentState {
+	case "LostContact", "Missing":
+		return true
+	}
+	return agent.BuildState == "Idle"
+}
+
+// agentBuildingStage returns the run of the stage the agent is building a job
+// of, read from the link to the stage, e.g.
+// https://ci.example.com/go/pipelines/up42/2/up42_stage/1
+func agentBuildingStage(agent *Agent) (pipeline string, pipelineCounter int, stage string, stageCounter int, ok bool) {
+	link, found := agent.BuildDetails.Links["stage"]
+	if !found {
+		return "", 0, "", 0, false
+	}
+	u, err := url.Parse(link.Href)
+	if err != nil {
+		return "", 0, "", 0, false
+	}
+	segments := strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+	if len(segments) < 4 {
+		return "", 0, "", 0, false
+	}
+	segments = segments[len(segments)-4:]
+	pipelineCounter, err1 := strconv.Atoi(segments[1])
+	stageCounter, err2 := strconv.Atoi(segments[3])
+	if err1 != nil || err2 != nil {
+		return "", 0, "", 0, false
+	}
+	return segments[0], pipelineCounter, segments[2], stageCounter, true
+}
@@ -0,0 +1,203 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testFleet fakes the agents of a GoCD server. An agent is building until it
+// was polled busyPolls times, or until its stage is cancelled.
+type testFleet struct {
+	mu        sync.Mutex
+	busyPolls map[string]int
+	polls     map[string]int
+	cancelled bool
+	// undeletable agents are refused deletion
+	undeletable map[string]bool
+	deleted     []string
+}
+
+func (f *testFleet) agent(uuid string) *Agent {
+	agent := &Agent{UUID: uuid, AgentConfigState: "Disabled", AgentState: "Idle", BuildState: "Idle"}
+	if f.polls[uuid] < f.busyPolls[uuid] && !f.cancelled {
+		agent.AgentState, agent.BuildState = "Building", "Building"
+		agent.BuildDetails.PipelineName = "up42"
+		agent.BuildDetails.Links = map[string]LinkInJSON{
+			"stage": {Href: "https://ci.example.com/go/pipelines/up42/2/up42_stage/1"},
+		}
+	}
+	return agent
+}
+
+func newTestFleetServer(t *testing.T, fleet *testFleet) (Client, *httptest.Server) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	mux.HandleFunc("/go/api/agents/", func(w http.ResponseWriter, r *http.Request) {
+		fleet.mu.Lock()
+		defer fleet.mu.Unlock()
+		uuid := path.Base(r.URL.Path)
+		switch r.Method {
+		case http.MethodPatch:
+			var update Agent
+			json.NewDecoder(r.Body).Decode(&update)
+			assert.Equal(t, "Disabled", update.AgentConfigState)
+		case http.MethodGet:
+			fleet.polls[uuid]++
+		case http.MethodDelete:
+			if fleet.undeletable[uuid] {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"message": "Failed to delete agent"}`))
+				return
+			}
+			fleet.deleted = append(fleet.deleted, uuid)
+			w.Write([]byte(`{"message": "Deleted 1 agent(s)."}`))
+			return
+		}
+		json.NewEncoder(w).Encode(fleet.agent(uuid))
+	})
+	mux.HandleFunc("/go/api/stages/up42/2/up42_stage/1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "POST")
+		fleet.mu.Lock()
+		defer fleet.mu.Unlock()
+		fleet.cancelled = true
+		w.Write([]byte(`{"message": "Stage cancelled successfully."}`))
+	})
+	server := httptest.NewServer(mux)
+	return New(server.URL, testUsername, testPassword), server
+}
+
+func newTestFleet(busyPolls map[string]int) *testFleet {
+	return &testFleet{busyPolls: busyPolls, polls: map[string]int{}, undeletable: map[string]bool{}}
+}
+
+func TestDrainAgent(t *testing.T) {
+	t.Parallel()
+	fleet := newTestFleet(map[string]int{"uuid1": 3})
+	client, server := newTestFleetServer(t, fleet)
+	defer server.Close()
+
+	steps := []string{}
+	agent, err := client.DrainAgent(context.Background(), "uuid1", DrainOptions{
+		PollInterval: time.Millisecond,
+		OnProgress:   func(p AgentProgress) { steps = append(steps, p.Step) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Idle", agent.BuildState)
+	assert.Equal(t, []string{AgentDisabled, AgentIdle}, steps)
+	assert.Equal(t, 3, fleet.polls["uuid1"])
+	assert.Empty(t, fleet.deleted)
+}
+
+func TestDrainAgentCancelsStage(t *testing.T) {
+	t.Parallel()
+	fleet := newTestFleet(map[string]int{"uuid1": 1000})
+	client, server := newTestFleetServer(t, fleet)
+	defer server.Close()
+
+	steps := []string{}
+	agent, err := client.DrainAgent(context.Background(), "uuid1", DrainOptions{
+		PollInterval: time.Millisecond,
+		CancelAfter:  10 * time.Millisecond,
+		OnProgress:   func(p AgentProgress) { steps = append(steps, p.Step) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Idle", agent.BuildState)
+	assert.True(t, fleet.cancelled)
+	assert.Equal(t, []string{AgentDisabled, AgentStageCancelled, AgentIdle}, steps)
+}
+
+func TestDrainAgentTimeout(t *testing.T) {
+	t.Parallel()
+	fleet := newTestFleet(map[string]int{"uuid1": 1000})
+	client, server := newTestFleetServer(t, fleet)
+	defer server.Close()
+
+	agent, err := client.DrainAgent(context.Background(), "uuid1", DrainOptions{PollInterval: time.Millisecond, Timeout: 50 * time.Millisecond})
+	assert.Equal(t, ErrDrainTimeout, err)
+	assert.Equal(t, "Building", agent.BuildState)
+	assert.False(t, fleet.cancelled)
+}
+
+func TestDecommissionAgents(t *testing.T) {
+	t.Parallel()
+	fleet := newTestFleet(map[string]int{"uuid1": 2, "uuid3": 5})
+	fleet.undeletable["uuid2"] = true
+	client, server := newTestFleetServer(t, fleet)
+	defer server.Close()
+
+	progress := map[string][]string{}
+	outcomes, err := client.DecommissionAgents(context.Background(), []string{"uuid1", "uuid2", "uuid3"}, DrainOptions{
+		PollInterval: time.Millisecond,
+		OnProgress:   func(p AgentProgress) { progress[p.UUID] = append(progress[p.UUID], p.Step) },
+	})
+	assert.EqualError(t, err, "gocd: 1 of 3 agents couldn't be decommissioned")
+	assert.Equal(t, 3, len(outcomes))
+
+	assert.Equal(t, "uuid1", outcomes[0].UUID)
+	assert.True(t, outcomes[0].Deleted)
+	assert.NoError(t, outcomes[0].Err)
+	assert.Equal(t, []string{AgentDisabled, AgentIdle, AgentDeleted}, progress["uuid1"])
+
+	assert.Equal(t, "uuid2", outcomes[1].UUID)
+	assert.False(t, outcomes[1].Deleted)
+	assert.Contains(t, outcomes[1].Err.Error(), "Failed to delete agent")
+	assert.Equal(t, "Idle", outcomes[1].Agent.BuildState)
+	assert.Equal(t, []string{AgentDisabled, AgentIdle}, progress["uuid2"])
+
+	assert.True(t, outcomes[2].Deleted)
+	assert.ElementsMatch(t, []string{"uuid1", "uuid3"}, fleet.deleted)
+}
+
+func TestDecommissionAgentsConcurrency(t *testing.T) {
+	t.Parallel()
+	uuids := []string{"uuid1", "uuid2", "uuid3", "uuid4", "uuid5"}
+	busyPolls := map[string]int{}
+	for _, uuid := range uuids {
+		busyPolls[uuid] = 3
+	}
+	fleet := newTestFleet(busyPolls)
+	client, server := newTestFleetServer(t, fleet)
+	defer server.Close()
+
+	draining, max := 0, 0
+	outcomes, err := client.DecommissionAgents(context.Background(), uuids, DrainOptions{
+		PollInterval: time.Millisecond,
+		Concurrency:  2,
+		OnProgress: func(p AgentProgress) {
+			switch p.Step {
+			case AgentDisabled:
+				if draining++; draining > max {
+					max = draining
+				}
+			case AgentDeleted:
+				draining--
+			}
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(outcomes))
+	assert.Equal(t, 2, max)
+	assert.ElementsMatch(t, uuids, fleet.deleted)
+}
+
+func TestAgentBuildingStage(t *testing.T) {
+	agent := &Agent{}
+	_, _, _, _, ok := agentBuildingStage(agent)
+	assert.False(t, ok)
+
+	agent.BuildDetails.Links = map[string]LinkInJSON{"stage": {Href: "https://ci.example.com/go/pipelines/up42/2/up42_stage/1"}}
+	pipeline, pipelineCounter, stage, stageCounter, ok := agentBuildingStage(agent)
+	assert.True(t, ok)
+	assert.Equal(t, "up42", pipeline)
+	assert.Equal(t, 2, pipelineCounter)
+	assert.Equal(t, "up42_stage", stage)
+	assert.Equal(t, 1, stageCounter)
+}
@@ -1,10 +1,11 @@
 package gocd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-
-	multierror "github.com/hashicorp/go-multierror"
+	"net/http"
+	"net/url"
 )
 
 // EnvironmentConfig Object
@@ -13,20 +14,54 @@ type EnvironmentConfig struct {
 	Pipelines            []string              `json:"pipelines"`
 	Agents               []string              `json:"agents"`
 	EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
+
+	// ETag identifies the version of the environment GoCD sent, it's required
+	// to update it. See UpdateEnvironmentConfig.
+	ETag string `json:"-"`
 }
 
-// UnmarshalJSON overriding it for the dynamic material attributes type
-func (ec *EnvironmentConfig) UnmarshalJSON(b []byte) error {
-	type pipeline struct {
-		Name string `json:"name"`
+// environmentPipeline and environmentAgent are how GoCD refers to the
+// pipelines and agents of an environment
+type environmentPipeline struct {
+	Name string `json:"name"`
+}
+
+type environmentAgent struct {
+	UUID string `json:"uuid"`
+}
+
+// MarshalJSON sends the pipelines and agents as the objects GoCD expects
+func (ec EnvironmentConfig) MarshalJSON() ([]byte, error) {
+	type tmpConfig struct {
+		Name                 string                `json:"name,omitempty"`
+		Pipelines            []environmentPipeline `json:"pipelines"`
+		Agents               []environmentAgent    `json:"agents"`
+		EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
+	}
+	tmpec := tmpConfig{
+		Name:                 ec.Name,
+		Pipelines:            []environmentPipeline{},
+		Agents:               []environmentAgent{},
+		EnvironmentVariables: ec.EnvironmentVariables,
+	}
+	for _, name := range ec.Pipelines {
+		tmpec.Pipelines = append(tmpec.Pipelines, environmentPipeline{name})
 	}
-	type agent struct {
-		UUID string `json:"uuid"`
+	for _, uuid := range ec.Agents {
+		tmpec.Agents = append(tmpec.Agents, environmentAgent{uuid})
 	}
+	if tmpec.EnvironmentVariables == nil {
+		tmpec.EnvironmentVariables = []EnvironmentVariable{}
+	}
+	return json.Marshal(tmpec)
+}
+
+// UnmarshalJSON overriding it for the dynamic material attributes type
+func (ec *EnvironmentConfig) UnmarshalJSON(b []byte) error {
 	type tmpConfig struct {
 		Name                 string                `json:"name,omitempty"`
-		Pipelines            []pipeline            `json:"pipelines"`
-		Agents               []agent               `json:"agents"`
+		Pipelines            []environmentPipeline `json:"pipelines"`
+		Agents               []environmentAgent    `json:"agents"`
 		EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
 	}
 	var tmpec *tmpConfig
@@ -46,51 +81,148 @@ func (ec *EnvironmentConfig) UnmarshalJSON(b []byte) error {
 
 // GetAllEnvironmentConfigs - Lists all available environments.
 func (c *DefaultClient) GetAllEnvironmentConfigs() ([]*EnvironmentConfig, error) {
-	var errors *multierror.Error
-
-	_, body, errs := c.Request.
-		Get(c.resolve("/go/api/admin/environments")).
-		Set("Accept", "application/vnd.go.cd.v2+json").
-		End()
-	if errs != nil {
-		errors = multierror.Append(errors, errs...)
-		return []*EnvironmentConfig{}, errors.ErrorOrNil()
-	}
+	return c.GetAllEnvironmentConfigsContext(context.Background())
+}
 
+// GetAllEnvironmentConfigsContext - Same as GetAllEnvironmentConfigs, bound to the given context
+func (c *DefaultClient) GetAllEnvironmentConfigsContext(ctx context.Context) ([]*EnvironmentConfig, error) {
 	type EmbeddedObj struct {
 		Environments []*EnvironmentConfig `json:"environments"`
 	}
 	type AllAgentsResponse struct {
 		Embedded EmbeddedObj `json:"_embedded"`
 	}
-	var responseFormat *AllAgentsResponse
+	var responseFormat AllAgentsResponse
 
-	jsonErr := json.Unmarshal([]byte(body), &responseFormat)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
-		return []*EnvironmentConfig{}, errors.ErrorOrNil()
+	headers := c.negotiate(ctx, apiEnvironments).headers()
+	if err := c.do(ctx, http.MethodGet, "/api/admin/environments", headers, nil, &responseFormat); err != nil {
+		return []*EnvironmentConfig{}, err
 	}
-	return responseFormat.Embedded.Environments, errors.ErrorOrNil()
+	return responseFormat.Embedded.Environments, nil
 }
 
 // GetEnvironmentConfig - Gets environment config for specified environment name.
 func (c *DefaultClient) GetEnvironmentConfig(name string) (*EnvironmentConfig, error) {
-	var errors *multierror.Error
+	return c.GetEnvironmentConfigContext(context.Background(), name)
+}
+
+// GetEnvironmentConfigContext - Same as GetEnvironmentConfig, bound to the given context
+func (c *DefaultClient) GetEnvironmentConfigContext(ctx context.Context, name string) (*EnvironmentConfig, error) {
+	environment := new(EnvironmentConfig)
 
-	_, body, errs := c.Request.
-		Get(c.resolve(fmt.Sprintf("/go/api/admin/environments/%s", name))).
-		Set("Accept", "application/vnd.go.cd.v2+json").
-		End()
-	errors = multierror.Append(errors, errs...)
-	if errs != nil {
-		return nil, errors.ErrorOrNil()
+	headers := c.negotiate(ctx, apiEnvironments).headers()
+	resp, err := c.doResponse(ctx, http.MethodGet, fmt.Sprintf("/api/admin/environments/%s", url.PathEscape(name)), headers, nil, environment)
+	if err != nil {
+		return nil, err
 	}
+	environment.ETag = resp.Header.Get("ETag")
+	return environment, nil
+}
 
-	var environment *EnvironmentConfig
+// EnvironmentConfigPatch lists the pipelines, agents and environment
+// variables to add to or to remove from an environment
+type EnvironmentConfigPatch struct {
+	AddPipelines               []string
+	RemovePipelines            []string
+	AddAgents                  []string
+	RemoveAgents               []string
+	AddEnvironmentVariables    []EnvironmentVariable
+	RemoveEnvironmentVariables []string
+}
 
-	jsonErr := json.Unmarshal([]byte(body), &environment)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
+// MarshalJSON sends the patch in the shape GoCD expects, e.g.
+// {"pipelines": {"add": ["up42"], "remove": []}}
+func (p EnvironmentConfigPatch) MarshalJSON() ([]byte, error) {
+	type changes struct {
+		Add    interface{} `json:"add"`
+		Remove []string    `json:"remove"`
+	}
+	patch := map[string]changes{}
+	if len(p.AddPipelines) > 0 || len(p.RemovePipelines) > 0 {
+		patch["pipelines"] = changes{nonNil(p.AddPipelines), nonNil(p.RemovePipelines)}
 	}
-	return environment, errors.ErrorOrNil()
+	if len(p.AddAgents) > 0 || len(p.RemoveAgents) > 0 {
+		patch["agents"] = changes{nonNil(p.AddAgents), nonNil(p.RemoveAgents)}
+	}
+	if len(p.AddEnvironmentVariables) > 0 || len(p.RemoveEnvironmentVariables) > 0 {
+		add := p.AddEnvironmentVariables
+		if add == nil {
+			add = []EnvironmentVariable{}
+		}
+		patch["environment_variables"] = changes{add, nonNil(p.RemoveEnvironmentVariables)}
+	}
+	return json.Marshal(patch)
+}
+
+// CreateEnvironmentConfig - Creates an environment.
+// Returns the created environment
+func (c *DefaultClient) CreateEnvironmentConfig(environment *EnvironmentConfig) (*EnvironmentConfig, error) {
+	return c.CreateEnvironmentConfigContext(context.Background(), environment)
+}
+
+// CreateEnvironmentConfigContext - Same as CreateEnvironmentConfig, bound to the given context
+func (c *DefaultClient) CreateEnvironmentConfigContext(ctx context.Context, environment *EnvironmentConfig) (*EnvironmentConfig, error) {
+	created := new(EnvironmentConfig)
+
+	headers := c.negotiate(ctx, apiEnvironments).headers()
+	resp, err := c.doResponse(ctx, http.MethodPost, "/api/admin/environments", headers, environment, created)
+	if err != nil {
+		return nil, err
+	}
+	created.ETag = resp.Header.Get("ETag")
+	return created, nil
+}
+
+// UpdateEnvironmentConfig - Replaces an environment with the given one, provided
+// it's still the version identified by environment.ETag: see IsConflict otherwise.
+// Returns the updated environment, along with its new ETag
+func (c *DefaultClient) UpdateEnvironmentConfig(environment *EnvironmentConfig) (*EnvironmentConfig, error) {
+	return c.UpdateEnvironmentConfigContext(context.Background(), environment)
+}
+
+// UpdateEnvironmentConfigContext - Same as UpdateEnvironmentConfig, bound to the given context
+func (c *DefaultClient) UpdateEnvironmentConfigContext(ctx context.Context, environment *EnvironmentConfig) (*EnvironmentConfig, error) {
+	updated := new(EnvironmentConfig)
+
+	headers, err := ifMatch(c.negotiate(ctx, apiEnvironments).headers(), environment.ETag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doResponse(ctx, http.MethodPut, fmt.Sprintf("/api/admin/environments/%s", url.PathEscape(environment.Name)), headers, environment, updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.ETag = resp.Header.Get("ETag")
+	return updated, nil
+}
+
+// PatchEnvironmentConfig - Adds or removes pipelines, agents and environment
+// variables of an environment, whatever its version.
+// Returns the updated environment
+func (c *DefaultClient) PatchEnvironmentConfig(name string, patch EnvironmentConfigPatch) (*EnvironmentConfig, error) {
+	return c.PatchEnvironmentConfigContext(context.Background(), name, patch)
+}
+
+// PatchEnvironmentConfigContext - Same as PatchEnvironmentConfig, bound to the given context
+func (c *DefaultClient) PatchEnvironmentConfigContext(ctx context.Context, name string, patch EnvironmentConfigPatch) (*EnvironmentConfig, error) {
+	patched := new(EnvironmentConfig)
+
+	headers := c.negotiate(ctx, apiEnvironments).headers()
+	resp, err := c.doResponse(ctx, http.MethodPatch, fmt.Sprintf("/api/admin/environments/%s", url.PathEscape(name)), headers, patch, patched)
+	if err != nil {
+		return nil, err
+	}
+	patched.ETag = resp.Header.Get("ETag")
+	return patched, nil
+}
+
+// DeleteEnvironmentConfig - Deletes an environment
+func (c *DefaultClient) DeleteEnvironmentConfig(name string) error {
+	return c.DeleteEnvironmentConfigContext(context.Background(), name)
+}
+
+// DeleteEnvironmentConfigContext - Same as DeleteEnvironmentConfig, bound to the given context
+func (c *DefaultClient) DeleteEnvironmentConfigContext(ctx context.Context, name string) error {
+	headers := c.negotiate(ctx, apiEnvironments).headers()
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/admin/environments/%s", url.PathEscape(name)), headers, nil, nil)
 }
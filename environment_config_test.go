@@ -1,6 +1,9 @@
 package gocd
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,13 +37,16 @@ func TestGetAllEnvironments(t *testing.T) {
 	assert.Equal(t, "12345678-e2f6-4c78-123456789012", env1.Agents[0])
 }
 
+const testEnvironmentConfigETag = `"26b227605daf6f2d7768c8edaf61b861"`
+
 func TestGetEnvironment(t *testing.T) {
 	t.Parallel()
-	client, server := newTestAPIClient("/go/api/admin/environments/my_environment", serveFileAsJSON(t, "GET", "test-fixtures/get_environment_config.json", 2, DummyRequestBodyValidator))
+	client, server := newTestAPIClient("/go/api/admin/environments/my_environment", serveWithETag(testEnvironmentConfigETag, serveFileAsJSON(t, "GET", "test-fixtures/get_environment_config.json", 2, DummyRequestBodyValidator)))
 	defer server.Close()
 	env, err := client.GetEnvironmentConfig("my_environment")
 	assert.NoError(t, err)
 	assert.NotNil(t, env)
+	assert.Equal(t, testEnvironmentConfigETag, env.ETag)
 	assert.Equal(t, "my_environment", env.Name)
 	assert.Equal(t, 2, len(env.EnvironmentVariables))
 	var1 := env.EnvironmentVariables[0]
@@ -58,3 +64,108 @@ func TestGetEnvironment(t *testing.T) {
 	assert.Equal(t, 1, len(env.Agents))
 	assert.Equal(t, "12345678-e2f6-4c78-123456789012", env.Agents[0])
 }
+
+func TestEnvironmentConfigMarshalJSON(t *testing.T) {
+	body, err := json.Marshal(&EnvironmentConfig{
+		Name:      "my_environment",
+		Pipelines: []string{"up42"},
+		Agents:    []string{"12345678-e2f6-4c78-123456789012"},
+		ETag:      testEnvironmentConfigETag,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"my_environment","pipelines":[{"name":"up42"}],"agents":[{"uuid":"12345678-e2f6-4c78-123456789012"}],"environment_variables":[]}`, string(body))
+
+	// what's marshaled reads back the same
+	var env EnvironmentConfig
+	assert.NoError(t, json.Unmarshal(body, &env))
+	assert.Equal(t, []string{"up42"}, env.Pipelines)
+	assert.Equal(t, []string{"12345678-e2f6-4c78-123456789012"}, env.Agents)
+}
+
+func TestCreateEnvironment(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"name":"my_environment","pipelines":[{"name":"up42"}],"agents":[{"uuid":"12345678-e2f6-4c78-123456789012"}],"environment_variables":[{"secure":false,"name":"username","value":"admin"},{"secure":true,"name":"password","encrypted_value":"LSd1TI0eLa+DjytHjj0qjA=="}]}`
+	client, server := newTestAPIClient("/go/api/admin/environments", serveWithETag(testEnvironmentConfigETag, serveFileAsJSON(t, "POST", "test-fixtures/get_environment_config.json", 2, jsonBodyValidator(expectedBody))))
+	defer server.Close()
+	env, err := client.CreateEnvironmentConfig(&EnvironmentConfig{
+		Name:      "my_environment",
+		Pipelines: []string{"up42"},
+		Agents:    []string{"12345678-e2f6-4c78-123456789012"},
+		EnvironmentVariables: []EnvironmentVariable{
+			{Name: "username", Value: "admin"},
+			{Secure: true, Name: "password", EncryptedValue: "LSd1TI0eLa+DjytHjj0qjA=="},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "my_environment", env.Name)
+	assert.Equal(t, []string{"up42"}, env.Pipelines)
+	assert.Equal(t, testEnvironmentConfigETag, env.ETag)
+}
+
+func TestUpdateEnvironment(t *testing.T) {
+	t.Parallel()
+	const newETag = `"9c1e0d3f4b5a6978e8d7c6b5a4f3e2d1"`
+	client, server := newTestAPIClient("/go/api/admin/environments/my_environment", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "PUT")
+		if r.Header.Get("If-Match") != testEnvironmentConfigETag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"message": "Someone has modified the configuration for environment 'my_environment'. Please update your copy of the config with the changes."}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name":"my_environment","pipelines":[{"name":"up42"},{"name":"down42"}],"agents":[],"environment_variables":[]}`, string(body))
+		w.Header().Set("ETag", newETag)
+		w.Write(body)
+	})
+	defer server.Close()
+
+	env := &EnvironmentConfig{Name: "my_environment", Pipelines: []string{"up42", "down42"}, ETag: testEnvironmentConfigETag}
+	updated, err := client.UpdateEnvironmentConfig(env)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"up42", "down42"}, updated.Pipelines)
+	assert.Equal(t, newETag, updated.ETag)
+
+	// the environment changed since
+	_, err = client.UpdateEnvironmentConfig(updated)
+	assert.True(t, IsConflict(err))
+}
+
+func TestUpdateEnvironmentWithoutETag(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/environments/my_environment", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s without an ETag", r.Method)
+	})
+	defer server.Close()
+
+	_, err := client.UpdateEnvironmentConfig(&EnvironmentConfig{Name: "my_environment"})
+	assert.Equal(t, ErrMissingETag, err)
+}
+
+func TestPatchEnvironment(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"pipelines":{"add":["up42"],"remove":["down42"]},"environment_variables":{"add":[{"secure":false,"name":"username","value":"admin"}],"remove":["password"]}}`
+	client, server := newTestAPIClient("/go/api/admin/environments/my_environment", serveFileAsJSON(t, "PATCH", "test-fixtures/get_environment_config.json", 2, jsonBodyValidator(expectedBody)))
+	defer server.Close()
+	env, err := client.PatchEnvironmentConfig("my_environment", EnvironmentConfigPatch{
+		AddPipelines:               []string{"up42"},
+		RemovePipelines:            []string{"down42"},
+		AddEnvironmentVariables:    []EnvironmentVariable{{Name: "username", Value: "admin"}},
+		RemoveEnvironmentVariables: []string{"password"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"up42"}, env.Pipelines)
+}
+
+func TestEnvironmentConfigPatchOnlySendsGivenChanges(t *testing.T) {
+	body, err := json.Marshal(EnvironmentConfigPatch{RemoveAgents: []string{"12345678-e2f6-4c78-123456789012"}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"agents":{"add":[],"remove":["12345678-e2f6-4c78-123456789012"]}}`, string(body))
+}
+
+func TestDeleteEnvironment(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/environments/my_environment", serveFileAsJSON(t, "DELETE", "test-fixtures/delete_environment_config.json", 2, DummyRequestBodyValidator))
+	defer server.Close()
+	err := client.DeleteEnvironmentConfig("my_environment")
+	assert.NoError(t, err)
+}
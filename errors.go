@@ -0,0 +1,88 @@
+package gocd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned whenever GoCD answers a request with an unsuccessful
+// (4xx or 5xx) status code. Message and Data hold the JSON body GoCD sends back
+// along with most errors, e.g. the validation errors of a 422.
+type APIError struct {
+	StatusCode int             `json:"-"`
+	Method     string          `json:"-"`
+	URL        string          `json:"-"`
+	Message    string          `json:"message"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("gocd: %s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// newAPIError builds an APIError out of an unsuccessful response and its body.
+// The body isn't always JSON (GoCD answers some 404s with an HTML page), in
+// which case only the request details and the status are filled.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = redactURL(resp.Request.URL)
+	}
+	var payload struct {
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Message = payload.Message
+		apiErr.Data = payload.Data
+	}
+	return apiErr
+}
+
+// hasStatus reports whether err is (or wraps) an APIError with the given status
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err comes from a 404 Not Found answer
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err comes from a 401 Unauthorized answer,
+// i.e. missing or invalid credentials
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err comes from a 403 Forbidden answer, i.e. the
+// user is authenticated but lacks the permission for this action
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsUnprocessableEntity reports whether err comes from a 422 Unprocessable
+// Entity answer, which GoCD uses for validation errors
+func IsUnprocessableEntity(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+// IsConflict reports whether err comes from a 412 Precondition Failed answer,
+// which GoCD sends when a config is updated with a stale ETag, i.e. it changed
+// since it was fetched, or from a 409 Conflict answer
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed) || hasStatus(err, http.StatusConflict)
+}
+
+// ErrMissingETag is returned, without sending the update, when a config is updated
+// without the ETag of the version it replaces, e.g. a struct built by hand
+// rather than fetched from GoCD
+var ErrMissingETag = errors.New("gocd: the ETag of the config to update is missing")
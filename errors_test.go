@@ -0,0 +1,64 @@
+package gocd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAgentNotFound(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/agents/unknown", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.go.cd.v6+json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Either the resource you requested was not found, or you are not authorized to perform this action."}`))
+	})
+	defer server.Close()
+
+	_, err := client.GetAgent("unknown")
+	assert.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsUnauthorized(err))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, server.URL+"/go/api/agents/unknown", apiErr.URL)
+	assert.Equal(t, "Either the resource you requested was not found, or you are not authorized to perform this action.", apiErr.Message)
+}
+
+func TestGetPipelineStatusUnauthorized(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("<html>Unauthorized</html>"))
+	})
+	defer server.Close()
+
+	_, err := client.GetPipelineStatus("pipeline1")
+	assert.True(t, IsUnauthorized(err))
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Empty(t, apiErr.Message)
+}
+
+func TestUnlockPipelineValidationError(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/unlock", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Lock cannot be released", "data": {"name": "pipeline1"}}`))
+	})
+	defer server.Close()
+
+	_, err := client.UnlockPipeline("pipeline1")
+	assert.True(t, IsUnprocessableEntity(err))
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "POST", apiErr.Method)
+	assert.Equal(t, "Lock cannot be released", apiErr.Message)
+	assert.JSONEq(t, `{"name": "pipeline1"}`, string(apiErr.Data))
+	assert.Contains(t, apiErr.Error(), "422 Unprocessable Entity: Lock cannot be released")
+}
@@ -1,6 +1,7 @@
 package gocd_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ashwanthkumar/go-gocd"
@@ -60,6 +61,22 @@ func ExampleDefaultClient_GetPipelineHistoryPage() {
 	}
 }
 
+// ExampleDefaultClient_IteratePipelineHistory displays the runs of a pipeline
+// from the 100th one, letting the iterator fetch the pages of its history.
+func ExampleDefaultClient_IteratePipelineHistory() {
+	client := gocd.New("http://localhost:8153", "admin", "badger")
+	ctx := context.Background()
+
+	it := client.IteratePipelineHistory("my-pipeline-name", gocd.HistoryBounds{MinCounter: 100})
+	for it.Next(ctx) {
+		p := it.Value()
+		fmt.Printf("Run #%d pipeline %s was triggered by %s\n", p.Counter, p.Name, p.BuildCause.TriggerMessage)
+	}
+	if err := it.Err(); err != nil {
+		fmt.Println(err)
+	}
+}
+
 // ExampleDefaultClient_GetPipelineStatus shows an example on how to use
 // GetPipelineStatus
 func ExampleDefaultClient_GetPipelineStatus() {
@@ -0,0 +1,231 @@
+package gocd
+
+import (
+	"context"
+	"time"
+)
+
+// HistoryBounds restricts the runs a history iterator goes through. The zero
+// value goes through the whole history.
+//
+// GoCD lists the pipeline history most recent run first, so its iteration
+// stops at the first run scheduled before Since or whose pipeline counter is
+// below MinCounter, without fetching the older pages. The job histories are
+// ordered by job instead: a rerun, or a later stage of an older pipeline run,
+// comes before more recent runs. Their iteration skips the jobs out of the
+// bounds and goes through all the pages.
+type HistoryBounds struct {
+	// Since and Until bound the time the runs were scheduled at, when not zero
+	Since time.Time
+	Until time.Time
+	// MinCounter and MaxCounter bound the pipeline counter of the runs, when
+	// not zero
+	MinCounter int
+	MaxCounter int
+}
+
+// check tells whether a run scheduled at the given time for the given pipeline
+// counter is within the bounds, and whether older runs may still be
+func (b HistoryBounds) check(scheduled time.Time, counter int) (within, more bool) {
+	if !b.Since.IsZero() && !scheduled.IsZero() && scheduled.Before(b.Since) {
+		return false, false
+	}
+	if b.MinCounter > 0 && counter < b.MinCounter {
+		return false, false
+	}
+	if !b.Until.IsZero() && scheduled.After(b.Until) {
+		return false, true
+	}
+	if b.MaxCounter > 0 && counter > b.MaxCounter {
+		return false, true
+	}
+	return true, true
+}
+
+// pager walks through the pages of a paginated history, fetching them lazily
+type pager[T any] struct {
+	fetch  func(ctx context.Context, offset int) ([]T, Pagination, error)
+	check  func(T) (within, more bool)
+	page   []T
+	offset int
+	last   bool
+	value  T
+	err    error
+}
+
+func (p *pager[T]) next(ctx context.Context) bool {
+	var zero T
+	p.value = zero
+	for p.err == nil {
+		if len(p.page) == 0 {
+			if p.last {
+				return false
+			}
+			items, pagination, err := p.fetch(ctx, p.offset)
+			if err != nil {
+				p.err = err
+				return false
+			}
+			// GoCD doesn't always fill page_size in, fall back on what it sent
+			step := pagination.PageSize
+			if step <= 0 {
+				step = len(items)
+			}
+			p.offset = pagination.Offset + step
+			p.last = len(items) == 0 || p.offset >= pagination.Total
+			p.page = items
+			continue
+		}
+
+		item := p.page[0]
+		p.page = p.page[1:]
+		within, more := p.check(item)
+		if !more {
+			p.page, p.last = nil, true
+			return false
+		}
+		if within {
+			p.value = item
+			return true
+		}
+	}
+	return false
+}
+
+// PipelineHistoryIterator goes through the runs of a pipeline, most recent
+// first, fetching the pages of its history as needed.
+// usage:
+//
+//	it := client.IteratePipelineHistory("my-pipeline", gocd.HistoryBounds{MinCounter: 100})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Value().Counter)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PipelineHistoryIterator struct {
+	pager pager[*PipelineInstance]
+}
+
+// Next advances to the next run, fetching the next page when needed. It
+// returns false when there is no more run within the bounds or on error, see
+// Err.
+func (it *PipelineHistoryIterator) Next(ctx context.Context) bool {
+	return it.pager.next(ctx)
+}
+
+// Value returns the current run
+func (it *PipelineHistoryIterator) Value() *PipelineInstance {
+	return it.pager.value
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *PipelineHistoryIterator) Err() error {
+	return it.pager.err
+}
+
+// JobHistoryIterator goes through job runs, most recent first, fetching the
+// pages of their history as needed. It works like PipelineHistoryIterator.
+type JobHistoryIterator struct {
+	pager pager[*JobHistory]
+}
+
+// Next advances to the next job run, fetching the next page when needed. It
+// returns false when there is no more run within the bounds or on error, see
+// Err.
+func (it *JobHistoryIterator) Next(ctx context.Context) bool {
+	return it.pager.next(ctx)
+}
+
+// Value returns the current job run
+func (it *JobHistoryIterator) Value() *JobHistory {
+	return it.pager.value
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *JobHistoryIterator) Err() error {
+	return it.pager.err
+}
+
+// IteratePipelineHistory returns an iterator over the runs of the given
+// pipeline within the given bounds. Nothing is fetched until its Next method
+// is called.
+func (c *DefaultClient) IteratePipelineHistory(name string, bounds HistoryBounds) *PipelineHistoryIterator {
+	return &PipelineHistoryIterator{pager[*PipelineInstance]{
+		fetch: func(ctx context.Context, offset int) ([]*PipelineInstance, Pagination, error) {
+			page, err := c.GetPipelineHistoryPageContext(ctx, name, offset)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+			instances := make([]*PipelineInstance, len(page.Pipelines))
+			for i := range page.Pipelines {
+				instances[i] = &page.Pipelines[i]
+			}
+			return instances, page.Pagination, nil
+		},
+		check: func(p *PipelineInstance) (bool, bool) {
+			return bounds.check(p.ScheduledAt(), p.Counter)
+		},
+	}}
+}
+
+// IterateJobHistory returns an iterator over the runs of the given job within
+// the given bounds. Nothing is fetched until its Next method is called.
+func (c *DefaultClient) IterateJobHistory(pipeline, stage, job string, bounds HistoryBounds) *JobHistoryIterator {
+	return &JobHistoryIterator{pager[*JobHistory]{
+		fetch: func(ctx context.Context, offset int) ([]*JobHistory, Pagination, error) {
+			page, err := c.getJobHistoryPage(ctx, pipeline, stage, job, offset)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+			return page.Jobs, page.Pagination, nil
+		},
+		check: bounds.checkJob,
+	}}
+}
+
+// IterateAgentRunJobHistory returns an iterator over the jobs which ran on
+// the given agent within the given bounds. Nothing is fetched until its Next
+// method is called.
+func (c *DefaultClient) IterateAgentRunJobHistory(uuid string, bounds HistoryBounds) *JobHistoryIterator {
+	return &JobHistoryIterator{pager[*JobHistory]{
+		fetch: func(ctx context.Context, offset int) ([]*JobHistory, Pagination, error) {
+			page, err := c.AgentRunJobHistoryContext(ctx, uuid, offset)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+			return page.Jobs, page.Pagination, nil
+		},
+		check: bounds.checkJob,
+	}}
+}
+
+// checkJob never stops the iteration, since the jobs aren't ordered by
+// pipeline counter nor by time
+func (b HistoryBounds) checkJob(j *JobHistory) (bool, bool) {
+	within, _ := b.check(millisToTime(int64(j.ScheduledDate)), j.PipelineCounter)
+	return within, true
+}
+
+// ScheduledAt returns the time the first job of the pipeline run was scheduled
+// at, or the zero time if it has no job
+func (p *PipelineInstance) ScheduledAt() time.Time {
+	var first int64
+	for _, stage := range p.Stages {
+		for _, job := range stage.Jobs {
+			if job.ScheduledDate > 0 && (first == 0 || job.ScheduledDate < first) {
+				first = job.ScheduledDate
+			}
+		}
+	}
+	return millisToTime(first)
+}
+
+// millisToTime converts the timestamps GoCD sends, in milliseconds since the
+// epoch, leaving 0 as the zero time
+func millisToTime(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
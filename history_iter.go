@@ -0,0 +1,43 @@
+//go:build go1.23
+
+package gocd
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns the remaining runs as a range-over-func sequence, bound to the
+// given context. An error ends the sequence, and is yielded with a nil run.
+// usage:
+//
+//	for p, err := range client.IteratePipelineHistory("my-pipeline", gocd.HistoryBounds{}).All(ctx) {
+//		...
+//	}
+func (it *PipelineHistoryIterator) All(ctx context.Context) iter.Seq2[*PipelineInstance, error] {
+	return func(yield func(*PipelineInstance, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// All returns the remaining job runs as a range-over-func sequence, bound to
+// the given context. An error ends the sequence, and is yielded with a nil run.
+func (it *JobHistoryIterator) All(ctx context.Context) iter.Seq2[*JobHistory, error] {
+	return func(yield func(*JobHistory, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+//go:build go1.23
+
+package gocd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineHistoryIteratorAll(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	client, server := newTestHistoryServer(t, 25, &pages)
+	defer server.Close()
+
+	counters := []int{}
+	for p, err := range client.IteratePipelineHistory("pipeline1", HistoryBounds{}).All(context.Background()) {
+		assert.NoError(t, err)
+		counters = append(counters, p.Counter)
+		if len(counters) == 12 {
+			break
+		}
+	}
+	assert.Equal(t, 14, counters[11])
+	assert.Equal(t, int32(2), pages)
+}
+
+func TestJobHistoryIteratorAllError(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/jobs/pipeline1/stage1/job1/history/0", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer server.Close()
+
+	var errs []error
+	for job, err := range client.IterateJobHistory("pipeline1", "stage1", "job1", HistoryBounds{}).All(context.Background()) {
+		assert.Nil(t, job)
+		errs = append(errs, err)
+	}
+	assert.Equal(t, 1, len(errs))
+	assert.True(t, IsUnauthorized(errs[0]))
+}
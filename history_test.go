@@ -0,0 +1,205 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testHistoryStart is the time the run #1 of the test pipeline was scheduled
+// at, the next runs are scheduled an hour apart
+var testHistoryStart = time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestHistoryServer serves the history of a pipeline which ran runs times,
+// 10 runs per page, the most recent first. Every page fetched is counted.
+func newTestHistoryServer(t *testing.T, runs int, pages *int32) (Client, *httptest.Server) {
+	return newTestAPIClient("/go/api/pipelines/pipeline1/history/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(pages, 1)
+		offset, err := strconv.Atoi(path.Base(r.URL.Path))
+		if err != nil {
+			t.Errorf("unexpected offset in %s", r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		page := PipelineHistoryPage{Pagination: Pagination{Offset: offset, Total: runs, PageSize: 10}}
+		for counter := runs - offset; counter > 0 && counter > runs-offset-10; counter-- {
+			scheduled := testHistoryStart.Add(time.Duration(counter-1) * time.Hour)
+			page.Pipelines = append(page.Pipelines, PipelineInstance{
+				Name:    "pipeline1",
+				Counter: counter,
+				Stages: []StageRun{{
+					Name: "stage1",
+					Jobs: []Job{{Name: "job1", ScheduledDate: scheduled.UnixNano() / int64(time.Millisecond)}},
+				}},
+			})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+}
+
+func collectCounters(t *testing.T, it *PipelineHistoryIterator) []int {
+	counters := []int{}
+	for it.Next(context.Background()) {
+		counters = append(counters, it.Value().Counter)
+	}
+	assert.NoError(t, it.Err())
+	return counters
+}
+
+func TestIteratePipelineHistory(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	client, server := newTestHistoryServer(t, 25, &pages)
+	defer server.Close()
+
+	counters := collectCounters(t, client.IteratePipelineHistory("pipeline1", HistoryBounds{}))
+	assert.Equal(t, 25, len(counters))
+	assert.Equal(t, 25, counters[0])
+	assert.Equal(t, 1, counters[24])
+	assert.Equal(t, int32(3), pages)
+}
+
+func TestIteratePipelineHistoryCounterRange(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	client, server := newTestHistoryServer(t, 25, &pages)
+	defer server.Close()
+
+	counters := collectCounters(t, client.IteratePipelineHistory("pipeline1", HistoryBounds{MinCounter: 12, MaxCounter: 20}))
+	assert.Equal(t, []int{20, 19, 18, 17, 16, 15, 14, 13, 12}, counters)
+	// the last page, holding runs #5 to #1, is never fetched
+	assert.Equal(t, int32(2), pages)
+}
+
+func TestIteratePipelineHistoryTimeWindow(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	client, server := newTestHistoryServer(t, 25, &pages)
+	defer server.Close()
+
+	bounds := HistoryBounds{
+		Since: testHistoryStart.Add(21 * time.Hour),
+		Until: testHistoryStart.Add(23 * time.Hour),
+	}
+	counters := collectCounters(t, client.IteratePipelineHistory("pipeline1", bounds))
+	assert.Equal(t, []int{24, 23, 22}, counters)
+	assert.Equal(t, int32(1), pages)
+}
+
+func TestIteratePipelineHistoryStopEarly(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	client, server := newTestHistoryServer(t, 25, &pages)
+	defer server.Close()
+
+	it := client.IteratePipelineHistory("pipeline1", HistoryBounds{})
+	assert.Equal(t, int32(0), pages)
+	for i := 0; i < 10; i++ {
+		assert.True(t, it.Next(context.Background()))
+	}
+	assert.Equal(t, 16, it.Value().Counter)
+	assert.Equal(t, int32(1), pages)
+}
+
+func TestIteratePipelineHistoryError(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/history/0", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	it := client.IteratePipelineHistory("pipeline1", HistoryBounds{})
+	assert.False(t, it.Next(context.Background()))
+	assert.Nil(t, it.Value())
+	assert.True(t, IsNotFound(it.Err()))
+}
+
+func TestIterateJobHistory(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/jobs/pipeline1/stage1/job1/history/0", serveFileAsJSON(t, "GET", "test-fixtures/get_job_history.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+
+	it := client.IterateJobHistory("pipeline1", "stage1", "job1", HistoryBounds{})
+	jobs := []*JobHistory{}
+	for it.Next(context.Background()) {
+		jobs = append(jobs, it.Value())
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 2, len(jobs))
+	assert.Equal(t, "job1", jobs[0].Name)
+}
+
+func TestIterateAgentRunJobHistory(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	client, server := newTestAPIClient("/go/api/agents/uuid/job_run_history/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pages, 1)
+		offset, _ := strconv.Atoi(path.Base(r.URL.Path))
+		page := JobRunHistory{Pagination: Pagination{Offset: offset, Total: 15, PageSize: 10}}
+		for counter := 15 - offset; counter > 0 && counter > 5-offset; counter-- {
+			page.Jobs = append(page.Jobs, &JobHistory{Name: fmt.Sprintf("job%d", counter), PipelineCounter: counter})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	defer server.Close()
+
+	it := client.IterateAgentRunJobHistory("uuid", HistoryBounds{MaxCounter: 12})
+	names := []string{}
+	for it.Next(context.Background()) {
+		names = append(names, it.Value().Name)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 12, len(names))
+	assert.Equal(t, "job12", names[0])
+	assert.Equal(t, int32(2), pages)
+}
+
+func TestIterateJobHistoryOutOfOrder(t *testing.T) {
+	t.Parallel()
+	var pages int32
+	// the jobs are listed by job, the rerun of the run #3 comes first
+	counters := [][]int{{3, 6, 5}, {2, 4, 3}, {1}}
+	client, server := newTestAPIClient("/go/api/jobs/pipeline1/stage1/job1/history/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pages, 1)
+		offset, _ := strconv.Atoi(path.Base(r.URL.Path))
+		page := JobRunHistory{Pagination: Pagination{Offset: offset, Total: 7, PageSize: 3}}
+		for _, counter := range counters[offset/3] {
+			scheduled := testHistoryStart.Add(time.Duration(counter-1) * time.Hour)
+			page.Jobs = append(page.Jobs, &JobHistory{Name: "job1", PipelineCounter: counter, ScheduledDate: int(scheduled.UnixNano() / int64(time.Millisecond))})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	defer server.Close()
+
+	collect := func(bounds HistoryBounds) []int {
+		it := client.IterateJobHistory("pipeline1", "stage1", "job1", bounds)
+		found := []int{}
+		for it.Next(context.Background()) {
+			found = append(found, it.Value().PipelineCounter)
+		}
+		assert.NoError(t, it.Err())
+		return found
+	}
+	assert.Equal(t, []int{6, 5, 4}, collect(HistoryBounds{MinCounter: 4}))
+	assert.Equal(t, []int{3, 4, 3}, collect(HistoryBounds{Since: testHistoryStart.Add(2 * time.Hour), Until: testHistoryStart.Add(3 * time.Hour)}))
+	assert.Equal(t, int32(6), pages)
+}
+
+func TestPipelineInstanceScheduledAt(t *testing.T) {
+	p := PipelineInstance{Stages: []StageRun{
+		{Jobs: []Job{{ScheduledDate: 1436172201081}, {ScheduledDate: 1436172200000}}},
+		{Jobs: []Job{{ScheduledDate: 1436172300000}}},
+	}}
+	assert.Equal(t, int64(1436172200000), p.ScheduledAt().UnixNano()/int64(time.Millisecond))
+	assert.True(t, (&PipelineInstance{}).ScheduledAt().IsZero())
+}
@@ -1,11 +1,11 @@
 package gocd
 
 import (
-	"encoding/json"
+	"context"
 	"encoding/xml"
 	"fmt"
-
-	multierror "github.com/hashicorp/go-multierror"
+	"net/http"
+	"net/url"
 )
 
 // Job definition used also by other elements like the pipeline and stages
@@ -55,27 +55,23 @@ type LinkInXML struct {
 
 // GetScheduledJobs - Lists all the current job instances which are scheduled but not yet assigned to any agent.
 func (c *DefaultClient) GetScheduledJobs() ([]*ScheduledJob, error) {
-	var errors *multierror.Error
+	return c.GetScheduledJobsContext(context.Background())
+}
 
+// GetScheduledJobsContext - Same as GetScheduledJobs, bound to the given context
+func (c *DefaultClient) GetScheduledJobsContext(ctx context.Context) ([]*ScheduledJob, error) {
 	type ScheduledJobsResponse struct {
 		XMLName xml.Name        `xml:"scheduledJobs"`
 		Jobs    []*ScheduledJob `xml:"job"`
 	}
 
 	var jobs ScheduledJobsResponse
-	_, body, errs := c.Request.
-		Get(c.resolve("/go/api/jobs/scheduled.xml")).
-		End()
-	if errs != nil {
-		errors = multierror.Append(errors, errs...)
-		return []*ScheduledJob{}, errors.ErrorOrNil()
-	}
-	xmlErr := xml.Unmarshal([]byte(body), &jobs)
-	if xmlErr != nil {
-		errors = multierror.Append(errors, xmlErr)
+	var body []byte
+	if err := c.do(ctx, http.MethodGet, "/api/jobs/scheduled.xml", nil, nil, &body); err != nil {
+		return []*ScheduledJob{}, err
 	}
-
-	return jobs.Jobs, errors.ErrorOrNil()
+	err := xml.Unmarshal(body, &jobs)
+	return jobs.Jobs, err
 }
 
 // JobHistory - Represents an instance of a job from the past
@@ -102,6 +98,7 @@ type JobStateTransition struct {
 	State           string `json:"state,omitempty"`
 }
 
+// JobRunHistory - A page of job instances, e.g. the jobs which ran on an agent
 type JobRunHistory struct {
 	Jobs       []*JobHistory `json:"jobs"`
 	Pagination Pagination    `json:"pagination"`
@@ -109,25 +106,23 @@ type JobRunHistory struct {
 
 // GetJobHistory - The job history allows users to list job instances of specified job. Supports pagination using offset which tells the API how many instances to skip.
 func (c *DefaultClient) GetJobHistory(pipeline, stage, job string, offset int) ([]*JobHistory, error) {
-	var errors *multierror.Error
-	_, body, errs := c.Request.
-		Get(c.resolve(fmt.Sprintf("/go/api/jobs/%s/%s/%s/history/%d", pipeline, stage, job, offset))).
-		// 18.6.0: providing API version here results in "resource not found"
-		Set("Accept", "application/json").
-		End()
-	if errs != nil {
-		errors = multierror.Append(errors, errs...)
-		return []*JobHistory{}, errors.ErrorOrNil()
-	}
+	return c.GetJobHistoryContext(context.Background(), pipeline, stage, job, offset)
+}
 
-	type JobHistoryResponse struct {
-		Jobs []*JobHistory `json:"jobs"`
-	}
-	jobs := JobHistoryResponse{}
-	jsonErr := json.Unmarshal([]byte(body), &jobs)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
-		return []*JobHistory{}, errors.ErrorOrNil()
+// GetJobHistoryContext - Same as GetJobHistory, bound to the given context
+func (c *DefaultClient) GetJobHistoryContext(ctx context.Context, pipeline, stage, job string, offset int) ([]*JobHistory, error) {
+	page, err := c.getJobHistoryPage(ctx, pipeline, stage, job, offset)
+	if err != nil {
+		return []*JobHistory{}, err
 	}
-	return jobs.Jobs, nil
+	return page.Jobs, nil
+}
+
+// getJobHistoryPage returns a page of the job history along with its pagination
+func (c *DefaultClient) getJobHistoryPage(ctx context.Context, pipeline, stage, job string, offset int) (*JobRunHistory, error) {
+	page := new(JobRunHistory)
+	headers := c.negotiate(ctx, apiJobHistory).headers()
+	resource := fmt.Sprintf("/api/jobs/%s/%s/%s/history/%d", url.PathEscape(pipeline), url.PathEscape(stage), url.PathEscape(job), offset)
+	err := c.do(ctx, http.MethodGet, resource, headers, nil, page)
+	return page, err
 }
@@ -1,66 +1,351 @@
 package gocd
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
-
-	multierror "github.com/hashicorp/go-multierror"
-	"github.com/parnurzeal/gorequest"
 )
 
+// DefaultBasePath is the path GoCD is served under, unless WithBasePath is given
+const DefaultBasePath = "/go"
+
+// DefaultTimeout is the timeout applied to the connections to the GoCD server
+// unless WithTimeout or WithHTTPClient is given
+const DefaultTimeout = 60 * time.Second
+
 // DefaultClient entrypoint for GoCD
+// All its methods are safe for concurrent use by multiple goroutines.
+//
+// The client used to expose the gorequest.SuperAgent it sent its requests
+// with as its Request field. It is now built on net/http: configure it with
+// the options of NewWithOptions instead (WithBasicAuth, WithTimeout,
+// WithTLSConfig, WithProxy or WithHTTPClient for anything else).
 type DefaultClient struct {
-	Host    string `json:"host"`
-	Request *gorequest.SuperAgent
+	Host string `json:"host"`
+
+	basePath      string
+	httpClient    *http.Client
+	streamClient  *http.Client
+	timeout       time.Duration
+	tlsConfig     *tls.Config
+	proxy         func(*http.Request) (*url.URL, error)
+	username      string
+	password      string
+	userAgent     string
+	tokenProvider TokenProvider
+	retryPolicy   RetryPolicy
+	rateLimiter   *rateLimiter
+	inFlight      chan struct{}
+
+	logger    Logger
+	hooks     Hooks
+	logBodies bool
+
+	serverVersionMu        sync.Mutex
+	serverVersion          *ServerVersion
+	serverVersionDetecting chan struct{}
+	serverVersionRetryAt   time.Time
 }
 
 // New GoCD Client
 func New(host, username, password string) Client {
+	return NewWithOptions(host, WithBasicAuth(username, password))
+}
+
+// NewWithOptions GoCD Client configured with the given options
+// usage:
+// client := gocd.NewWithOptions("https://gocd.example.com", gocd.WithBasicAuth("admin", "badger"), gocd.WithTLSConfig(tlsConfig))
+func NewWithOptions(host string, opts ...Option) Client {
 	client := DefaultClient{
-		Host:    host,
-		Request: gorequest.New().Timeout(60*time.Second).SetBasicAuth(username, password),
+		Host:     host,
+		basePath: DefaultBasePath,
+		timeout:  DefaultTimeout,
+		proxy:    http.ProxyFromEnvironment,
+	}
+	for _, opt := range opts {
+		opt(&client)
+	}
+
+	if client.httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = client.tlsConfig
+		transport.Proxy = client.proxy
+		transport.ResponseHeaderTimeout = client.timeout
+		// GoCD answers with a session cookie, sending it back spares it
+		// from authenticating every single request
+		jar, _ := cookiejar.New(nil)
+		client.httpClient = &http.Client{
+			Transport: transport,
+			Timeout:   client.timeout,
+			Jar:       jar,
+		}
+		// the transfer of a streamed body can take any time, only the wait
+		// for the answer is bounded, by the transport
+		client.streamClient = &http.Client{
+			Transport: transport,
+			Jar:       jar,
+		}
+	} else {
+		client.streamClient = client.httpClient
 	}
 	return &client
 }
 
+// resolve returns the absolute URL of the given API resource (e.g.
+// "/api/agents/my%20agent"), served under the client's base path. The
+// resource is expected to be already escaped.
 func (c *DefaultClient) resolve(resource string) string {
-	// TODO: Use a proper URL resolve to parse the string and append the resource
-	return c.Host + resource
+	u, err := url.Parse(c.Host)
+	if err != nil {
+		// let the request fail on the malformed host
+		return c.Host + c.basePath + resource
+	}
+	resource, query := splitQuery(resource)
+	escapedPath := strings.TrimSuffix(u.EscapedPath(), "/") + c.basePath + resource
+	if u.Path, err = url.PathUnescape(escapedPath); err != nil {
+		return c.Host + c.basePath + resource
+	}
+	u.RawPath = escapedPath
+	u.RawQuery = query
+	return u.String()
+}
+
+// splitQuery splits the query string, if any, from the given resource
+func splitQuery(resource string) (string, string) {
+	if i := strings.IndexByte(resource, '?'); i >= 0 {
+		return resource[:i], resource[i+1:]
+	}
+	return resource, ""
 }
 
-// getJSON executes a Get query against the given url with the given headers and
-// modify the out object given as reference
+// do executes a query against the given resource with the given headers,
+// sending the "in" object as JSON data (when not nil, url.Values are sent as a
+// form instead) and decoding the JSON answer into the "out" object given as
+// reference (when not nil and the answer isn't empty). Give a *[]byte as "out"
+// to get the raw answer instead.
 // usage:
-// err := c.getJSON(fmt.Sprintf("/go/api/pipelines/%s/history/%d", name, offset), nil, res)
-// err := c.getJSON(fmt.Sprintf("/go/api/pipelines/%s/history/%d", name, offset), map[string]string{"Accept": "application/vnd.go.cd.v2+json"}, res)
-func (c *DefaultClient) getJSON(url string, headers map[string]string, out interface{}) error {
-	var errors *multierror.Error
+// err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/pipelines/%s/history/%d", url.PathEscape(name), offset), nil, nil, res)
+// err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/pipelines/%s/pause", url.PathEscape(name)), map[string]string{"X-GoCD-Confirm": "true"}, data, res)
+func (c *DefaultClient) do(ctx context.Context, method, resource string, headers map[string]string, in, out interface{}) error {
+	_, err := c.doResponse(ctx, method, resource, headers, in, out)
+	return err
+}
+
+// doResponse is the same as do, also returning the response (whose body is
+// already read) for its headers, e.g. the ETag of a config
+func (c *DefaultClient) doResponse(ctx context.Context, method, resource string, headers map[string]string, in, out interface{}) (*http.Response, error) {
+	var body []byte
+	switch in := in.(type) {
+	case nil:
+	case url.Values:
+		body = []byte(in.Encode())
+		headers = withHeader(headers, "Content-Type", "application/x-www-form-urlencoded")
+	default:
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return nil, err
+		}
+		headers = withHeader(headers, "Content-Type", "application/json")
+	}
 
-	req := c.Request.Get(c.resolve(url))
+	resp, answer, err := c.send(ctx, method, resource, headers, body)
+	if err != nil || out == nil {
+		return resp, err
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = answer
+		return resp, nil
+	}
+	if len(bytes.TrimSpace(answer)) == 0 {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(answer, out)
+}
+
+// withHeader returns a copy of headers with the given one set
+func withHeader(headers map[string]string, key, value string) map[string]string {
+	copied := make(map[string]string, len(headers)+1)
 	for k, v := range headers {
-		req.Set(k, v)
+		copied[k] = v
 	}
+	copied[key] = value
+	return copied
+}
 
-	if _, _, errs := req.EndStruct(out); errs != nil {
-		errors = multierror.Append(errors, errs...)
+// ifMatch returns a copy of headers with the If-Match header GoCD requires to
+// update a config, or ErrMissingETag when etag is empty
+func ifMatch(headers map[string]string, etag string) (map[string]string, error) {
+	if etag == "" {
+		return nil, ErrMissingETag
 	}
-	return errors.ErrorOrNil()
+	return withHeader(headers, "If-Match", etag), nil
 }
 
-// postJSON executes a Post query against the given url with the given headers and
-// the using the given "in" struct as data, then modify the out object given as reference
-// Warning: if the body returned by gocd is empty, you will need
-// https://github.com/parnurzeal/gorequest/pull/185 to avoid json unmarshall
-// error messages
-func (c *DefaultClient) postJSON(url string, headers map[string]string, in, out interface{}) error {
-	var errors *multierror.Error
+// send executes the request and reads the whole answer. The request is bound
+// to ctx, so that a cancellation or a deadline aborts the in-flight call, and
+// any unsuccessful status code is reported as an *APIError.
+// Failed attempts are retried according to the client's RetryPolicy.
+func (c *DefaultClient) send(ctx context.Context, method, resource string, headers map[string]string, body []byte) (*http.Response, []byte, error) {
+	attempts := c.retryPolicy.attempts(method)
+	for attempt := 1; ; attempt++ {
+		resp, answer, err := c.sendOnce(ctx, method, resource, headers, body)
+		if attempt >= attempts || ctx.Err() != nil || !c.retryPolicy.retryable(resp, err) {
+			return resp, answer, err
+		}
 
-	req := c.Request.Post(c.resolve(url))
+		timer := time.NewTimer(c.retryPolicy.backoff(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, answer, err
+		case <-timer.C:
+		}
+	}
+}
+
+// sendOnce makes a single attempt at sending the request
+func (c *DefaultClient) sendOnce(ctx context.Context, method, resource string, headers map[string]string, body []byte) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := c.newRequest(ctx, method, resource, headers, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	c.traceRequest(ctx, req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.traceResponse(ctx, req, nil, nil, time.Since(start), err)
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	answer, err := ioutil.ReadAll(resp.Body)
+	c.traceResponse(ctx, req, resp, answer, time.Since(start), err)
+	if err != nil {
+		return resp, nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, answer, newAPIError(resp, answer)
+	}
+	return resp, answer, nil
+}
+
+// stream executes a request whose body and answer are streamed rather than
+// held in memory, e.g. to transfer artifacts. The caller must close the body
+// of the returned response. Any unsuccessful status code is reported as an
+// *APIError. Requests with a body are attempted once only since it can't be
+// sent again, the others are retried according to the client's RetryPolicy.
+func (c *DefaultClient) stream(ctx context.Context, method, resource string, headers map[string]string, body io.Reader) (*http.Response, error) {
+	attempts := 1
+	if body == nil {
+		attempts = c.retryPolicy.attempts(method)
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.streamOnce(ctx, method, resource, headers, body)
+		if attempt >= attempts || ctx.Err() != nil || !c.retryPolicy.retryable(resp, err) {
+			return resp, err
+		}
+
+		timer := time.NewTimer(c.retryPolicy.backoff(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+	}
+}
+
+// streamOnce makes a single attempt at streaming the request. The slot taken
+// by the request (see WithMaxConcurrentRequests) is only released once its
+// answer is closed.
+func (c *DefaultClient) streamOnce(ctx context.Context, method, resource string, headers map[string]string, body io.Reader) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, resource, headers, body)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.traceRequest(ctx, req)
+	start := time.Now()
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		release()
+		c.traceResponse(ctx, req, nil, nil, time.Since(start), err)
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		answer, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		release()
+		c.traceResponse(ctx, req, resp, answer, time.Since(start), err)
+		return resp, newAPIError(resp, answer)
+	}
+	c.traceResponse(ctx, req, resp, nil, time.Since(start), nil)
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody releases the slot of a streamed request once its answer is
+// closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// newRequest builds an authenticated request for the given resource, bound to ctx
+func (c *DefaultClient) newRequest(ctx context.Context, method, resource string, headers map[string]string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.resolve(resource), body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
 	for k, v := range headers {
-		req.Set(k, v)
+		req.Header.Set(k, v)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
 	}
 
-	if _, _, errs := req.SendStruct(in).End(); errs != nil {
-		errors = multierror.Append(errors, errs...)
+	switch {
+	case c.tokenProvider != nil:
+		token, err := c.tokenProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.username != "" || c.password != "":
+		req.SetBasicAuth(c.username, c.password)
 	}
-	return errors.ErrorOrNil()
+	return req, nil
 }
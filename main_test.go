@@ -0,0 +1,98 @@
+package gocd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConcurrentCalls hammers several endpoints from many goroutines sharing a
+// single client. Run it with -race: any request state shared between calls
+// shows up as a data race, or as headers / bodies leaking from one call into
+// another.
+func TestConcurrentCalls(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/go/api/agents/", serveFileAsJSON(t, "GET", "test-fixtures/get_agent.json", 6, DummyRequestBodyValidator))
+	mux.HandleFunc("/go/api/admin/environments/my_environment", serveFileAsJSON(t, "GET", "test-fixtures/get_environment_config.json", 2, DummyRequestBodyValidator))
+	mux.HandleFunc("/go/api/pipelines/", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "GET")
+		assert.Empty(t, r.Header.Get("Accept"))
+		w.Write([]byte(fmt.Sprintf(`{"pausedCause": %q, "paused": true}`, r.URL.Path)))
+	})
+	mux.HandleFunc("/go/api/server_health_messages", serveFileAsJSON(t, "GET", "test-fixtures/get_server_health_messages.json", 1, DummyRequestBodyValidator))
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := New(server.URL, testUsername, testPassword)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			agent, err := client.GetAgent("uuid")
+			assert.NoError(t, err)
+			assert.Equal(t, "adb9540a-b954-4571-9d9b-2f330739d4da", agent.UUID)
+		}()
+		go func() {
+			defer wg.Done()
+			env, err := client.GetEnvironmentConfig("my_environment")
+			assert.NoError(t, err)
+			assert.Equal(t, "my_environment", env.Name)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("pipeline%d", i)
+			status, err := client.GetPipelineStatus(name)
+			assert.NoError(t, err)
+			assert.Equal(t, "/go/api/pipelines/"+name+"/status", status.PausedCause)
+		}(i)
+		go func() {
+			defer wg.Done()
+			messages, err := client.GetServerHealthMessages()
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(messages))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestResolve(t *testing.T) {
+	tc := []struct {
+		host     string
+		opts     []Option
+		resource string
+		expected string
+	}{
+		{"http://localhost:8153", nil, "/api/agents", "http://localhost:8153/go/api/agents"},
+		{"http://localhost:8153/", nil, "/api/agents", "http://localhost:8153/go/api/agents"},
+		{"https://ci.example.com/gocd/", nil, "/api/agents", "https://ci.example.com/gocd/go/api/agents"},
+		{"https://ci.example.com", []Option{WithBasePath("/cd/")}, "/api/agents", "https://ci.example.com/cd/api/agents"},
+		{"https://ci.example.com/", []Option{WithBasePath("")}, "/api/agents", "https://ci.example.com/api/agents"},
+		{"http://localhost:8153", nil, "/api/pipelines/my%20pipeline%2Fv2/status", "http://localhost:8153/go/api/pipelines/my%20pipeline%2Fv2/status"},
+		{"http://localhost:8153", nil, "/api/materials?fingerprint=abc", "http://localhost:8153/go/api/materials?fingerprint=abc"},
+	}
+	for _, c := range tc {
+		client := NewWithOptions(c.host, c.opts...).(*DefaultClient)
+		assert.Equal(t, c.expected, client.resolve(c.resource))
+	}
+}
+
+func TestEscapedPipelineName(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/pipelines/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/go/api/pipelines/my pipeline/v2/status", r.URL.Path)
+		assert.Equal(t, "/go/api/pipelines/my%20pipeline%2Fv2/status", r.URL.EscapedPath())
+		w.Write([]byte(`{"paused": true}`))
+	})
+	defer server.Close()
+
+	status, err := client.GetPipelineStatus("my pipeline/v2")
+	assert.NoError(t, err)
+	assert.True(t, status.Paused)
+}
@@ -1,5 +1,12 @@
 package gocd
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
 // Material represents a material (Can be Git, Mercurial, Perforce, Subversion, Tfs, Pipeline, SCM)
 type Material struct {
 	ID          int    `json:"id"`
@@ -24,3 +31,81 @@ type MaterialRevision struct {
 	Modifications []MaterialModification `json:"modifications"`
 	Changed       bool                   `json:"changed"`
 }
+
+// MaterialModificationsPage is a page of the modifications of a material, the
+// most recent first
+type MaterialModificationsPage struct {
+	Modifications []MaterialModification `json:"modifications"`
+	Pagination    Pagination             `json:"pagination"`
+}
+
+// GetAllMaterials lists the materials used by the pipelines
+func (c *DefaultClient) GetAllMaterials() ([]*Material, error) {
+	return c.GetAllMaterialsContext(context.Background())
+}
+
+// GetAllMaterialsContext is the same as GetAllMaterials, bound to the given context
+func (c *DefaultClient) GetAllMaterialsContext(ctx context.Context) ([]*Material, error) {
+	materials := []*Material{}
+	headers := c.negotiate(ctx, apiMaterials).headers()
+	err := c.do(ctx, http.MethodGet, "/api/config/materials", headers, nil, &materials)
+	return materials, err
+}
+
+// GetMaterialModifications lists the modifications of the material with the
+// given fingerprint (see Material.Fingerprint). Supports pagination using
+// offset which tells the API how many modifications to skip.
+func (c *DefaultClient) GetMaterialModifications(fingerprint string, offset int) (*MaterialModificationsPage, error) {
+	return c.GetMaterialModificationsContext(context.Background(), fingerprint, offset)
+}
+
+// GetMaterialModificationsContext is the same as GetMaterialModifications, bound to the given context
+func (c *DefaultClient) GetMaterialModificationsContext(ctx context.Context, fingerprint string, offset int) (*MaterialModificationsPage, error) {
+	res := new(MaterialModificationsPage)
+	headers := c.negotiate(ctx, apiMaterialModifications).headers()
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/materials/%s/modifications/%d", url.PathEscape(fingerprint), offset), headers, nil, res)
+	return res, err
+}
+
+// NotifyGitMaterial tells GoCD to poll the git materials with the given
+// repository URL, typically from a post-commit hook
+func (c *DefaultClient) NotifyGitMaterial(repoURL string) (*SimpleMessage, error) {
+	return c.NotifyGitMaterialContext(context.Background(), repoURL)
+}
+
+// NotifyGitMaterialContext is the same as NotifyGitMaterial, bound to the given context
+func (c *DefaultClient) NotifyGitMaterialContext(ctx context.Context, repoURL string) (*SimpleMessage, error) {
+	return c.notifyMaterial(ctx, "git", url.Values{"repository_url": {repoURL}})
+}
+
+// NotifySVNMaterial tells GoCD to poll the subversion materials of the
+// repository with the given UUID, typically from a post-commit hook
+func (c *DefaultClient) NotifySVNMaterial(uuid string) (*SimpleMessage, error) {
+	return c.NotifySVNMaterialContext(context.Background(), uuid)
+}
+
+// NotifySVNMaterialContext is the same as NotifySVNMaterial, bound to the given context
+func (c *DefaultClient) NotifySVNMaterialContext(ctx context.Context, uuid string) (*SimpleMessage, error) {
+	return c.notifyMaterial(ctx, "svn", url.Values{"uuid": {uuid}})
+}
+
+// NotifyHgMaterial tells GoCD to poll the mercurial materials with the given
+// repository URL, typically from a changegroup hook. The GoCD versions which
+// can't be notified of mercurial changes answer with a 400 Bad Request.
+func (c *DefaultClient) NotifyHgMaterial(repoURL string) (*SimpleMessage, error) {
+	return c.NotifyHgMaterialContext(context.Background(), repoURL)
+}
+
+// NotifyHgMaterialContext is the same as NotifyHgMaterial, bound to the given context
+func (c *DefaultClient) NotifyHgMaterialContext(ctx context.Context, repoURL string) (*SimpleMessage, error) {
+	return c.notifyMaterial(ctx, "hg", url.Values{"repository_url": {repoURL}})
+}
+
+// notifyMaterial posts the given form to the notification endpoint of the
+// given material type. GoCD answers with a plain text message.
+func (c *DefaultClient) notifyMaterial(ctx context.Context, materialType string, form url.Values) (*SimpleMessage, error) {
+	var body []byte
+	headers := c.negotiate(ctx, apiMaterialNotify).headers()
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/material/notify/%s", materialType), headers, form, &body)
+	return parseMessage(body), err
+}
@@ -0,0 +1,88 @@
+package gocd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllMaterials(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/config/materials", serveFileAsJSON(t, "GET", "test-fixtures/get_all_materials.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+	materials, err := client.GetAllMaterials()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(materials))
+	assert.Equal(t, "Git", materials[0].Type)
+	assert.Equal(t, "f6e7a3899c55e1682ffb00383bdf8f882bcee2141e79a8728254190a1fddcf4f", materials[0].Fingerprint)
+	assert.Equal(t, "URL: https://github.com/gocd/gocd, Branch: master", materials[0].Description)
+	assert.Equal(t, "Subversion", materials[1].Type)
+}
+
+func TestGetMaterialModifications(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/materials/f6e7a3899c55e1682ffb00383bdf8f882bcee2141e79a8728254190a1fddcf4f/modifications/0", serveFileAsJSON(t, "GET", "test-fixtures/get_material_modifications.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+	page, err := client.GetMaterialModifications("f6e7a3899c55e1682ffb00383bdf8f882bcee2141e79a8728254190a1fddcf4f", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(page.Modifications))
+	modification := page.Modifications[0]
+	assert.Equal(t, 7225, modification.ID)
+	assert.Equal(t, int64(1435728005000), modification.ModifiedTime)
+	assert.Equal(t, "Pick E Reader <pick.e.reader@example.com>", modification.UserName)
+	assert.Equal(t, "my hola mundo changes", modification.Comment)
+	assert.Equal(t, "a788f1876e2e1f6e5a1e91006e75cd1d467a0edb", modification.Revision)
+	assert.Equal(t, Pagination{Offset: 0, Total: 2, PageSize: 10}, page.Pagination)
+}
+
+// serveMaterialNotification checks the notification form sent to GoCD, which
+// answers with a plain text message
+func serveMaterialNotification(t *testing.T, field, value string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "POST")
+		assert.Equal(t, "true", r.Header.Get("X-GoCD-Confirm"))
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, value, r.PostForm.Get(field))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("The material is now scheduled for an update. Please check relevant pipeline(s) for status.\n"))
+	}
+}
+
+func TestNotifyGitMaterial(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/material/notify/git", serveMaterialNotification(t, "repository_url", "https://github.com/gocd/gocd"))
+	defer server.Close()
+	msg, err := client.NotifyGitMaterial("https://github.com/gocd/gocd")
+	assert.NoError(t, err)
+	assert.Equal(t, "The material is now scheduled for an update. Please check relevant pipeline(s) for status.", msg.Message)
+}
+
+func TestNotifySVNMaterial(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/material/notify/svn", serveMaterialNotification(t, "uuid", "4a7c7c9e-2e0c-4b5f-9a4e-0e2a3d2f1b6c"))
+	defer server.Close()
+	_, err := client.NotifySVNMaterial("4a7c7c9e-2e0c-4b5f-9a4e-0e2a3d2f1b6c")
+	assert.NoError(t, err)
+}
+
+func TestNotifyHgMaterial(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/material/notify/hg", serveMaterialNotification(t, "repository_url", "https://hg.example.com/repo"))
+	defer server.Close()
+	_, err := client.NotifyHgMaterial("https://hg.example.com/repo")
+	assert.NoError(t, err)
+}
+
+func TestNotifyMaterialError(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/material/notify/git", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "No matching materials!"}`))
+	})
+	defer server.Close()
+	_, err := client.NotifyGitMaterial("https://github.com/unknown/repo")
+	assert.True(t, hasStatus(err, http.StatusBadRequest))
+	assert.Contains(t, err.Error(), "No matching materials!")
+}
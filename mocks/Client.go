@@ -1,5 +1,7 @@
 package mocks
 
+import "context"
+import "io"
 import "github.com/ashwanthkumar/go-gocd"
 import "github.com/stretchr/testify/mock"
 
@@ -30,6 +32,29 @@ func (_m *Client) GetAllAgents() ([]*gocd.Agent, error) {
 	return r0, r1
 }
 
+// GetAllAgentsContext provides a mock function with given fields: ctx
+func (_m *Client) GetAllAgentsContext(ctx context.Context) ([]*gocd.Agent, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.Agent
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.Agent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.Agent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAgent provides a mock function with given fields: uuid
 func (_m *Client) GetAgent(uuid string) (*gocd.Agent, error) {
 	ret := _m.Called(uuid)
@@ -53,6 +78,29 @@ func (_m *Client) GetAgent(uuid string) (*gocd.Agent, error) {
 	return r0, r1
 }
 
+// GetAgentContext provides a mock function with given fields: ctx, uuid
+func (_m *Client) GetAgentContext(ctx context.Context, uuid string) (*gocd.Agent, error) {
+	ret := _m.Called(ctx, uuid)
+
+	var r0 *gocd.Agent
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.Agent); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.Agent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateAgent provides a mock function with given fields: uuid, agent
 func (_m *Client) UpdateAgent(uuid string, agent *gocd.Agent) (*gocd.Agent, error) {
 	ret := _m.Called(uuid, agent)
@@ -76,6 +124,29 @@ func (_m *Client) UpdateAgent(uuid string, agent *gocd.Agent) (*gocd.Agent, erro
 	return r0, r1
 }
 
+// UpdateAgentContext provides a mock function with given fields: ctx, uuid, agent
+func (_m *Client) UpdateAgentContext(ctx context.Context, uuid string, agent *gocd.Agent) (*gocd.Agent, error) {
+	ret := _m.Called(ctx, uuid, agent)
+
+	var r0 *gocd.Agent
+	if rf, ok := ret.Get(0).(func(context.Context, string, *gocd.Agent) *gocd.Agent); ok {
+		r0 = rf(ctx, uuid, agent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.Agent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *gocd.Agent) error); ok {
+		r1 = rf(ctx, uuid, agent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisableAgent provides a mock function with given fields: uuid
 func (_m *Client) DisableAgent(uuid string) error {
 	ret := _m.Called(uuid)
@@ -90,6 +161,20 @@ func (_m *Client) DisableAgent(uuid string) error {
 	return r0
 }
 
+// DisableAgentContext provides a mock function with given fields: ctx, uuid
+func (_m *Client) DisableAgentContext(ctx context.Context, uuid string) error {
+	ret := _m.Called(ctx, uuid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnableAgent provides a mock function with given fields: uuid
 func (_m *Client) EnableAgent(uuid string) error {
 	ret := _m.Called(uuid)
@@ -104,6 +189,20 @@ func (_m *Client) EnableAgent(uuid string) error {
 	return r0
 }
 
+// EnableAgentContext provides a mock function with given fields: ctx, uuid
+func (_m *Client) EnableAgentContext(ctx context.Context, uuid string) error {
+	ret := _m.Called(ctx, uuid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAgent provides a mock function with given fields: uuid
 func (_m *Client) DeleteAgent(uuid string) error {
 	ret := _m.Called(uuid)
@@ -118,16 +217,122 @@ func (_m *Client) DeleteAgent(uuid string) error {
 	return r0
 }
 
+// DeleteAgentContext provides a mock function with given fields: ctx, uuid
+func (_m *Client) DeleteAgentContext(ctx context.Context, uuid string) error {
+	ret := _m.Called(ctx, uuid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BulkUpdateAgents provides a mock function with given fields: uuids, update
+func (_m *Client) BulkUpdateAgents(uuids []string, update gocd.BulkAgentUpdate) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(uuids, update)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func([]string, gocd.BulkAgentUpdate) *gocd.SimpleMessage); ok {
+		r0 = rf(uuids, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, gocd.BulkAgentUpdate) error); ok {
+		r1 = rf(uuids, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkUpdateAgentsContext provides a mock function with given fields: ctx, uuids, update
+func (_m *Client) BulkUpdateAgentsContext(ctx context.Context, uuids []string, update gocd.BulkAgentUpdate) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, uuids, update)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, []string, gocd.BulkAgentUpdate) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, uuids, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, gocd.BulkAgentUpdate) error); ok {
+		r1 = rf(ctx, uuids, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkDeleteAgents provides a mock function with given fields: uuids
+func (_m *Client) BulkDeleteAgents(uuids []string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(uuids)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func([]string) *gocd.SimpleMessage); ok {
+		r0 = rf(uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(uuids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkDeleteAgentsContext provides a mock function with given fields: ctx, uuids
+func (_m *Client) BulkDeleteAgentsContext(ctx context.Context, uuids []string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, uuids)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, uuids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, uuids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AgentRunJobHistory provides a mock function with given fields: uuid, offset
-func (_m *Client) AgentRunJobHistory(uuid string, offset int) ([]*gocd.JobHistory, error) {
+func (_m *Client) AgentRunJobHistory(uuid string, offset int) (*gocd.JobRunHistory, error) {
 	ret := _m.Called(uuid, offset)
 
-	var r0 []*gocd.JobHistory
-	if rf, ok := ret.Get(0).(func(string, int) []*gocd.JobHistory); ok {
+	var r0 *gocd.JobRunHistory
+	if rf, ok := ret.Get(0).(func(string, int) *gocd.JobRunHistory); ok {
 		r0 = rf(uuid, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*gocd.JobHistory)
+			r0 = ret.Get(0).(*gocd.JobRunHistory)
 		}
 	}
 
@@ -141,16 +346,101 @@ func (_m *Client) AgentRunJobHistory(uuid string, offset int) ([]*gocd.JobHistor
 	return r0, r1
 }
 
-// GetScheduledJobs provides a mock function with given fields:
-func (_m *Client) GetScheduledJobs() ([]*gocd.ScheduledJob, error) {
+// AgentRunJobHistoryContext provides a mock function with given fields: ctx, uuid, offset
+func (_m *Client) AgentRunJobHistoryContext(ctx context.Context, uuid string, offset int) (*gocd.JobRunHistory, error) {
+	ret := _m.Called(ctx, uuid, offset)
+
+	var r0 *gocd.JobRunHistory
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *gocd.JobRunHistory); ok {
+		r0 = rf(ctx, uuid, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.JobRunHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, uuid, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IterateAgentRunJobHistory provides a mock function with given fields: uuid, bounds
+func (_m *Client) IterateAgentRunJobHistory(uuid string, bounds gocd.HistoryBounds) *gocd.JobHistoryIterator {
+	ret := _m.Called(uuid, bounds)
+
+	var r0 *gocd.JobHistoryIterator
+	if rf, ok := ret.Get(0).(func(string, gocd.HistoryBounds) *gocd.JobHistoryIterator); ok {
+		r0 = rf(uuid, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.JobHistoryIterator)
+		}
+	}
+
+	return r0
+}
+
+// DrainAgent provides a mock function with given fields: ctx, uuid, opts
+func (_m *Client) DrainAgent(ctx context.Context, uuid string, opts gocd.DrainOptions) (*gocd.Agent, error) {
+	ret := _m.Called(ctx, uuid, opts)
+
+	var r0 *gocd.Agent
+	if rf, ok := ret.Get(0).(func(context.Context, string, gocd.DrainOptions) *gocd.Agent); ok {
+		r0 = rf(ctx, uuid, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.Agent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, gocd.DrainOptions) error); ok {
+		r1 = rf(ctx, uuid, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecommissionAgents provides a mock function with given fields: ctx, uuids, opts
+func (_m *Client) DecommissionAgents(ctx context.Context, uuids []string, opts gocd.DrainOptions) ([]gocd.AgentOutcome, error) {
+	ret := _m.Called(ctx, uuids, opts)
+
+	var r0 []gocd.AgentOutcome
+	if rf, ok := ret.Get(0).(func(context.Context, []string, gocd.DrainOptions) []gocd.AgentOutcome); ok {
+		r0 = rf(ctx, uuids, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gocd.AgentOutcome)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, gocd.DrainOptions) error); ok {
+		r1 = rf(ctx, uuids, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllUsers provides a mock function with given fields:
+func (_m *Client) GetAllUsers() ([]*gocd.User, error) {
 	ret := _m.Called()
 
-	var r0 []*gocd.ScheduledJob
-	if rf, ok := ret.Get(0).(func() []*gocd.ScheduledJob); ok {
+	var r0 []*gocd.User
+	if rf, ok := ret.Get(0).(func() []*gocd.User); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*gocd.ScheduledJob)
+			r0 = ret.Get(0).([]*gocd.User)
 		}
 	}
 
@@ -164,22 +454,2487 @@ func (_m *Client) GetScheduledJobs() ([]*gocd.ScheduledJob, error) {
 	return r0, r1
 }
 
-// GetJobHistory provides a mock function with given fields: pipeline, stage, job, offset
-func (_m *Client) GetJobHistory(pipeline string, stage string, job string, offset int) ([]*gocd.JobHistory, error) {
-	ret := _m.Called(pipeline, stage, job, offset)
+// GetAllUsersContext provides a mock function with given fields: ctx
+func (_m *Client) GetAllUsersContext(ctx context.Context) ([]*gocd.User, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []*gocd.JobHistory
-	if rf, ok := ret.Get(0).(func(string, string, string, int) []*gocd.JobHistory); ok {
-		r0 = rf(pipeline, stage, job, offset)
+	var r0 []*gocd.User
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.User); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*gocd.JobHistory)
+			r0 = ret.Get(0).([]*gocd.User)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, int) error); ok {
-		r1 = rf(pipeline, stage, job, offset)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: loginName
+func (_m *Client) GetUser(loginName string) (*gocd.User, error) {
+	ret := _m.Called(loginName)
+
+	var r0 *gocd.User
+	if rf, ok := ret.Get(0).(func(string) *gocd.User); ok {
+		r0 = rf(loginName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(loginName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserContext provides a mock function with given fields: ctx, loginName
+func (_m *Client) GetUserContext(ctx context.Context, loginName string) (*gocd.User, error) {
+	ret := _m.Called(ctx, loginName)
+
+	var r0 *gocd.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.User); ok {
+		r0 = rf(ctx, loginName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loginName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: user
+func (_m *Client) CreateUser(user *gocd.User) (*gocd.User, error) {
+	ret := _m.Called(user)
+
+	var r0 *gocd.User
+	if rf, ok := ret.Get(0).(func(*gocd.User) *gocd.User); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserContext provides a mock function with given fields: ctx, user
+func (_m *Client) CreateUserContext(ctx context.Context, user *gocd.User) (*gocd.User, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 *gocd.User
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.User) *gocd.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: loginName, update
+func (_m *Client) UpdateUser(loginName string, update gocd.UserUpdate) (*gocd.User, error) {
+	ret := _m.Called(loginName, update)
+
+	var r0 *gocd.User
+	if rf, ok := ret.Get(0).(func(string, gocd.UserUpdate) *gocd.User); ok {
+		r0 = rf(loginName, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, gocd.UserUpdate) error); ok {
+		r1 = rf(loginName, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserContext provides a mock function with given fields: ctx, loginName, update
+func (_m *Client) UpdateUserContext(ctx context.Context, loginName string, update gocd.UserUpdate) (*gocd.User, error) {
+	ret := _m.Called(ctx, loginName, update)
+
+	var r0 *gocd.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, gocd.UserUpdate) *gocd.User); ok {
+		r0 = rf(ctx, loginName, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, gocd.UserUpdate) error); ok {
+		r1 = rf(ctx, loginName, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUsers provides a mock function with given fields: loginNames
+func (_m *Client) DeleteUsers(loginNames []string) error {
+	ret := _m.Called(loginNames)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(loginNames)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUsersContext provides a mock function with given fields: ctx, loginNames
+func (_m *Client) DeleteUsersContext(ctx context.Context, loginNames []string) error {
+	ret := _m.Called(ctx, loginNames)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, loginNames)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllMaterials provides a mock function with given fields:
+func (_m *Client) GetAllMaterials() ([]*gocd.Material, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.Material
+	if rf, ok := ret.Get(0).(func() []*gocd.Material); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.Material)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllMaterialsContext provides a mock function with given fields: ctx
+func (_m *Client) GetAllMaterialsContext(ctx context.Context) ([]*gocd.Material, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.Material
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.Material); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.Material)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialModifications provides a mock function with given fields: fingerprint, offset
+func (_m *Client) GetMaterialModifications(fingerprint string, offset int) (*gocd.MaterialModificationsPage, error) {
+	ret := _m.Called(fingerprint, offset)
+
+	var r0 *gocd.MaterialModificationsPage
+	if rf, ok := ret.Get(0).(func(string, int) *gocd.MaterialModificationsPage); ok {
+		r0 = rf(fingerprint, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.MaterialModificationsPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(fingerprint, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterialModificationsContext provides a mock function with given fields: ctx, fingerprint, offset
+func (_m *Client) GetMaterialModificationsContext(ctx context.Context, fingerprint string, offset int) (*gocd.MaterialModificationsPage, error) {
+	ret := _m.Called(ctx, fingerprint, offset)
+
+	var r0 *gocd.MaterialModificationsPage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *gocd.MaterialModificationsPage); ok {
+		r0 = rf(ctx, fingerprint, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.MaterialModificationsPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, fingerprint, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyGitMaterial provides a mock function with given fields: repoURL
+func (_m *Client) NotifyGitMaterial(repoURL string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(repoURL)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string) *gocd.SimpleMessage); ok {
+		r0 = rf(repoURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(repoURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyGitMaterialContext provides a mock function with given fields: ctx, repoURL
+func (_m *Client) NotifyGitMaterialContext(ctx context.Context, repoURL string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, repoURL)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, repoURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, repoURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifySVNMaterial provides a mock function with given fields: uuid
+func (_m *Client) NotifySVNMaterial(uuid string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(uuid)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string) *gocd.SimpleMessage); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifySVNMaterialContext provides a mock function with given fields: ctx, uuid
+func (_m *Client) NotifySVNMaterialContext(ctx context.Context, uuid string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, uuid)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyHgMaterial provides a mock function with given fields: repoURL
+func (_m *Client) NotifyHgMaterial(repoURL string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(repoURL)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string) *gocd.SimpleMessage); ok {
+		r0 = rf(repoURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(repoURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyHgMaterialContext provides a mock function with given fields: ctx, repoURL
+func (_m *Client) NotifyHgMaterialContext(ctx context.Context, repoURL string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, repoURL)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, repoURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, repoURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineGroups provides a mock function with given fields:
+func (_m *Client) GetPipelineGroups() ([]*gocd.PipelineGroup, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.PipelineGroup
+	if rf, ok := ret.Get(0).(func() []*gocd.PipelineGroup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.PipelineGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineGroupsContext provides a mock function with given fields: ctx
+func (_m *Client) GetPipelineGroupsContext(ctx context.Context) ([]*gocd.PipelineGroup, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.PipelineGroup
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.PipelineGroup); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.PipelineGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllPipelineGroupConfigs provides a mock function with given fields:
+func (_m *Client) GetAllPipelineGroupConfigs() ([]*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func() []*gocd.PipelineGroupConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllPipelineGroupConfigsContext provides a mock function with given fields: ctx
+func (_m *Client) GetAllPipelineGroupConfigsContext(ctx context.Context) ([]*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.PipelineGroupConfig); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineGroupConfig provides a mock function with given fields: name
+func (_m *Client) GetPipelineGroupConfig(name string) (*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(name)
+
+	var r0 *gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(string) *gocd.PipelineGroupConfig); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineGroupConfigContext provides a mock function with given fields: ctx, name
+func (_m *Client) GetPipelineGroupConfigContext(ctx context.Context, name string) (*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.PipelineGroupConfig); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePipelineGroupConfig provides a mock function with given fields: group
+func (_m *Client) CreatePipelineGroupConfig(group *gocd.PipelineGroupConfig) (*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(group)
+
+	var r0 *gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(*gocd.PipelineGroupConfig) *gocd.PipelineGroupConfig); ok {
+		r0 = rf(group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.PipelineGroupConfig) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePipelineGroupConfigContext provides a mock function with given fields: ctx, group
+func (_m *Client) CreatePipelineGroupConfigContext(ctx context.Context, group *gocd.PipelineGroupConfig) (*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 *gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.PipelineGroupConfig) *gocd.PipelineGroupConfig); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.PipelineGroupConfig) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePipelineGroupConfig provides a mock function with given fields: group
+func (_m *Client) UpdatePipelineGroupConfig(group *gocd.PipelineGroupConfig) (*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(group)
+
+	var r0 *gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(*gocd.PipelineGroupConfig) *gocd.PipelineGroupConfig); ok {
+		r0 = rf(group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.PipelineGroupConfig) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePipelineGroupConfigContext provides a mock function with given fields: ctx, group
+func (_m *Client) UpdatePipelineGroupConfigContext(ctx context.Context, group *gocd.PipelineGroupConfig) (*gocd.PipelineGroupConfig, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 *gocd.PipelineGroupConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.PipelineGroupConfig) *gocd.PipelineGroupConfig); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineGroupConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.PipelineGroupConfig) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePipelineGroupConfig provides a mock function with given fields: name
+func (_m *Client) DeletePipelineGroupConfig(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePipelineGroupConfigContext provides a mock function with given fields: ctx, name
+func (_m *Client) DeletePipelineGroupConfigContext(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPipelineInstance provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetPipelineInstance(_a0 string, _a1 int) (*gocd.PipelineInstance, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gocd.PipelineInstance
+	if rf, ok := ret.Get(0).(func(string, int) *gocd.PipelineInstance); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineInstanceContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) GetPipelineInstanceContext(_a0 context.Context, _a1 string, _a2 int) (*gocd.PipelineInstance, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *gocd.PipelineInstance
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *gocd.PipelineInstance); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineHistoryPage provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetPipelineHistoryPage(_a0 string, _a1 int) (*gocd.PipelineHistoryPage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gocd.PipelineHistoryPage
+	if rf, ok := ret.Get(0).(func(string, int) *gocd.PipelineHistoryPage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineHistoryPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineHistoryPageContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) GetPipelineHistoryPageContext(_a0 context.Context, _a1 string, _a2 int) (*gocd.PipelineHistoryPage, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *gocd.PipelineHistoryPage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *gocd.PipelineHistoryPage); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineHistoryPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IteratePipelineHistory provides a mock function with given fields: name, bounds
+func (_m *Client) IteratePipelineHistory(name string, bounds gocd.HistoryBounds) *gocd.PipelineHistoryIterator {
+	ret := _m.Called(name, bounds)
+
+	var r0 *gocd.PipelineHistoryIterator
+	if rf, ok := ret.Get(0).(func(string, gocd.HistoryBounds) *gocd.PipelineHistoryIterator); ok {
+		r0 = rf(name, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineHistoryIterator)
+		}
+	}
+
+	return r0
+}
+
+// GetPipelineStatus provides a mock function with given fields: _a0
+func (_m *Client) GetPipelineStatus(_a0 string) (*gocd.PipelineStatus, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *gocd.PipelineStatus
+	if rf, ok := ret.Get(0).(func(string) *gocd.PipelineStatus); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineStatusContext provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetPipelineStatusContext(_a0 context.Context, _a1 string) (*gocd.PipelineStatus, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gocd.PipelineStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.PipelineStatus); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PausePipeline provides a mock function with given fields: _a0, _a1
+func (_m *Client) PausePipeline(_a0 string, _a1 string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, string) *gocd.SimpleMessage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PausePipelineContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) PausePipelineContext(_a0 context.Context, _a1 string, _a2 string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *gocd.SimpleMessage); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnpausePipeline provides a mock function with given fields: _a0
+func (_m *Client) UnpausePipeline(_a0 string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string) *gocd.SimpleMessage); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnpausePipelineContext provides a mock function with given fields: _a0, _a1
+func (_m *Client) UnpausePipelineContext(_a0 context.Context, _a1 string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.SimpleMessage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockPipeline provides a mock function with given fields: _a0
+func (_m *Client) UnlockPipeline(_a0 string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string) *gocd.SimpleMessage); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockPipelineContext provides a mock function with given fields: _a0, _a1
+func (_m *Client) UnlockPipelineContext(_a0 context.Context, _a1 string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.SimpleMessage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SchedulePipeline provides a mock function with given fields: name, opts
+func (_m *Client) SchedulePipeline(name string, opts gocd.ScheduleOptions) (*gocd.ScheduleResult, error) {
+	ret := _m.Called(name, opts)
+
+	var r0 *gocd.ScheduleResult
+	if rf, ok := ret.Get(0).(func(string, gocd.ScheduleOptions) *gocd.ScheduleResult); ok {
+		r0 = rf(name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.ScheduleResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, gocd.ScheduleOptions) error); ok {
+		r1 = rf(name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SchedulePipelineContext provides a mock function with given fields: ctx, name, opts
+func (_m *Client) SchedulePipelineContext(ctx context.Context, name string, opts gocd.ScheduleOptions) (*gocd.ScheduleResult, error) {
+	ret := _m.Called(ctx, name, opts)
+
+	var r0 *gocd.ScheduleResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, gocd.ScheduleOptions) *gocd.ScheduleResult); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.ScheduleResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, gocd.ScheduleOptions) error); ok {
+		r1 = rf(ctx, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledPipelineInstance provides a mock function with given fields: result
+func (_m *Client) GetScheduledPipelineInstance(result *gocd.ScheduleResult) (*gocd.PipelineInstance, error) {
+	ret := _m.Called(result)
+
+	var r0 *gocd.PipelineInstance
+	if rf, ok := ret.Get(0).(func(*gocd.ScheduleResult) *gocd.PipelineInstance); ok {
+		r0 = rf(result)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.ScheduleResult) error); ok {
+		r1 = rf(result)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledPipelineInstanceContext provides a mock function with given fields: ctx, result
+func (_m *Client) GetScheduledPipelineInstanceContext(ctx context.Context, result *gocd.ScheduleResult) (*gocd.PipelineInstance, error) {
+	ret := _m.Called(ctx, result)
+
+	var r0 *gocd.PipelineInstance
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.ScheduleResult) *gocd.PipelineInstance); ok {
+		r0 = rf(ctx, result)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.ScheduleResult) error); ok {
+		r1 = rf(ctx, result)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitForPipeline provides a mock function with given fields: ctx, name, counter, opts
+func (_m *Client) WaitForPipeline(ctx context.Context, name string, counter int, opts gocd.WaitOptions) (*gocd.PipelineResult, error) {
+	ret := _m.Called(ctx, name, counter, opts)
+
+	var r0 *gocd.PipelineResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, gocd.WaitOptions) *gocd.PipelineResult); ok {
+		r0 = rf(ctx, name, counter, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, gocd.WaitOptions) error); ok {
+		r1 = rf(ctx, name, counter, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineConfig provides a mock function with given fields: name
+func (_m *Client) GetPipelineConfig(name string) (*gocd.PipelineConfig, error) {
+	ret := _m.Called(name)
+
+	var r0 *gocd.PipelineConfig
+	if rf, ok := ret.Get(0).(func(string) *gocd.PipelineConfig); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineConfigContext provides a mock function with given fields: ctx, name
+func (_m *Client) GetPipelineConfigContext(ctx context.Context, name string) (*gocd.PipelineConfig, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *gocd.PipelineConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.PipelineConfig); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePipelineConfig provides a mock function with given fields: group, config
+func (_m *Client) CreatePipelineConfig(group string, config *gocd.PipelineConfig) (*gocd.PipelineConfig, error) {
+	ret := _m.Called(group, config)
+
+	var r0 *gocd.PipelineConfig
+	if rf, ok := ret.Get(0).(func(string, *gocd.PipelineConfig) *gocd.PipelineConfig); ok {
+		r0 = rf(group, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *gocd.PipelineConfig) error); ok {
+		r1 = rf(group, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePipelineConfigContext provides a mock function with given fields: ctx, group, config
+func (_m *Client) CreatePipelineConfigContext(ctx context.Context, group string, config *gocd.PipelineConfig) (*gocd.PipelineConfig, error) {
+	ret := _m.Called(ctx, group, config)
+
+	var r0 *gocd.PipelineConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string, *gocd.PipelineConfig) *gocd.PipelineConfig); ok {
+		r0 = rf(ctx, group, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *gocd.PipelineConfig) error); ok {
+		r1 = rf(ctx, group, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePipelineConfig provides a mock function with given fields: config
+func (_m *Client) UpdatePipelineConfig(config *gocd.PipelineConfig) (*gocd.PipelineConfig, error) {
+	ret := _m.Called(config)
+
+	var r0 *gocd.PipelineConfig
+	if rf, ok := ret.Get(0).(func(*gocd.PipelineConfig) *gocd.PipelineConfig); ok {
+		r0 = rf(config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.PipelineConfig) error); ok {
+		r1 = rf(config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePipelineConfigContext provides a mock function with given fields: ctx, config
+func (_m *Client) UpdatePipelineConfigContext(ctx context.Context, config *gocd.PipelineConfig) (*gocd.PipelineConfig, error) {
+	ret := _m.Called(ctx, config)
+
+	var r0 *gocd.PipelineConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.PipelineConfig) *gocd.PipelineConfig); ok {
+		r0 = rf(ctx, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.PipelineConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.PipelineConfig) error); ok {
+		r1 = rf(ctx, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePipelineConfig provides a mock function with given fields: name
+func (_m *Client) DeletePipelineConfig(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePipelineConfigContext provides a mock function with given fields: ctx, name
+func (_m *Client) DeletePipelineConfigContext(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllTemplates provides a mock function with given fields:
+func (_m *Client) GetAllTemplates() ([]*gocd.TemplateConfig, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func() []*gocd.TemplateConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllTemplatesContext provides a mock function with given fields: ctx
+func (_m *Client) GetAllTemplatesContext(ctx context.Context) ([]*gocd.TemplateConfig, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.TemplateConfig); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplate provides a mock function with given fields: name
+func (_m *Client) GetTemplate(name string) (*gocd.TemplateConfig, error) {
+	ret := _m.Called(name)
+
+	var r0 *gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(string) *gocd.TemplateConfig); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplateContext provides a mock function with given fields: ctx, name
+func (_m *Client) GetTemplateContext(ctx context.Context, name string) (*gocd.TemplateConfig, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.TemplateConfig); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTemplate provides a mock function with given fields: template
+func (_m *Client) CreateTemplate(template *gocd.TemplateConfig) (*gocd.TemplateConfig, error) {
+	ret := _m.Called(template)
+
+	var r0 *gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(*gocd.TemplateConfig) *gocd.TemplateConfig); ok {
+		r0 = rf(template)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.TemplateConfig) error); ok {
+		r1 = rf(template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTemplateContext provides a mock function with given fields: ctx, template
+func (_m *Client) CreateTemplateContext(ctx context.Context, template *gocd.TemplateConfig) (*gocd.TemplateConfig, error) {
+	ret := _m.Called(ctx, template)
+
+	var r0 *gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.TemplateConfig) *gocd.TemplateConfig); ok {
+		r0 = rf(ctx, template)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.TemplateConfig) error); ok {
+		r1 = rf(ctx, template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTemplate provides a mock function with given fields: template
+func (_m *Client) UpdateTemplate(template *gocd.TemplateConfig) (*gocd.TemplateConfig, error) {
+	ret := _m.Called(template)
+
+	var r0 *gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(*gocd.TemplateConfig) *gocd.TemplateConfig); ok {
+		r0 = rf(template)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.TemplateConfig) error); ok {
+		r1 = rf(template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTemplateContext provides a mock function with given fields: ctx, template
+func (_m *Client) UpdateTemplateContext(ctx context.Context, template *gocd.TemplateConfig) (*gocd.TemplateConfig, error) {
+	ret := _m.Called(ctx, template)
+
+	var r0 *gocd.TemplateConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.TemplateConfig) *gocd.TemplateConfig); ok {
+		r0 = rf(ctx, template)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.TemplateConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.TemplateConfig) error); ok {
+		r1 = rf(ctx, template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTemplate provides a mock function with given fields: name
+func (_m *Client) DeleteTemplate(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTemplateContext provides a mock function with given fields: ctx, name
+func (_m *Client) DeleteTemplateContext(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunStage provides a mock function with given fields: pipeline, pipelineCounter, stage
+func (_m *Client) RunStage(pipeline string, pipelineCounter int, stage string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, int, string) *gocd.SimpleMessage); ok {
+		r0 = rf(pipeline, pipelineCounter, stage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunStageContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage
+func (_m *Client) RunStageContext(ctx context.Context, pipeline string, pipelineCounter int, stage string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelStage provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter
+func (_m *Client) CancelStage(pipeline string, pipelineCounter int, stage string, stageCounter int) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, int, string, int) *gocd.SimpleMessage); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelStageContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter
+func (_m *Client) CancelStageContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RerunFailedJobs provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter
+func (_m *Client) RerunFailedJobs(pipeline string, pipelineCounter int, stage string, stageCounter int) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, int, string, int) *gocd.SimpleMessage); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RerunFailedJobsContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter
+func (_m *Client) RerunFailedJobsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RerunSelectedJobs provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, jobs
+func (_m *Client) RerunSelectedJobs(pipeline string, pipelineCounter int, stage string, stageCounter int, jobs []string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, jobs)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, int, string, int, []string) *gocd.SimpleMessage); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, jobs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, []string) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, jobs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RerunSelectedJobsContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, jobs
+func (_m *Client) RerunSelectedJobsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, jobs []string) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, jobs)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, []string) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, jobs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, []string) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, jobs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStageInstance provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter
+func (_m *Client) GetStageInstance(pipeline string, pipelineCounter int, stage string, stageCounter int) (*gocd.StageInstance, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter)
+
+	var r0 *gocd.StageInstance
+	if rf, ok := ret.Get(0).(func(string, int, string, int) *gocd.StageInstance); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.StageInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStageInstanceContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter
+func (_m *Client) GetStageInstanceContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*gocd.StageInstance, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter)
+
+	var r0 *gocd.StageInstance
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int) *gocd.StageInstance); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.StageInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStageHistory provides a mock function with given fields: pipeline, stage, offset
+func (_m *Client) GetStageHistory(pipeline string, stage string, offset int) (*gocd.StageHistoryPage, error) {
+	ret := _m.Called(pipeline, stage, offset)
+
+	var r0 *gocd.StageHistoryPage
+	if rf, ok := ret.Get(0).(func(string, string, int) *gocd.StageHistoryPage); ok {
+		r0 = rf(pipeline, stage, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.StageHistoryPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(pipeline, stage, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStageHistoryContext provides a mock function with given fields: ctx, pipeline, stage, offset
+func (_m *Client) GetStageHistoryContext(ctx context.Context, pipeline string, stage string, offset int) (*gocd.StageHistoryPage, error) {
+	ret := _m.Called(ctx, pipeline, stage, offset)
+
+	var r0 *gocd.StageHistoryPage
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *gocd.StageHistoryPage); ok {
+		r0 = rf(ctx, pipeline, stage, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.StageHistoryPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, pipeline, stage, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledJobs provides a mock function with given fields:
+func (_m *Client) GetScheduledJobs() ([]*gocd.ScheduledJob, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.ScheduledJob
+	if rf, ok := ret.Get(0).(func() []*gocd.ScheduledJob); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.ScheduledJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledJobsContext provides a mock function with given fields: ctx
+func (_m *Client) GetScheduledJobsContext(ctx context.Context) ([]*gocd.ScheduledJob, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.ScheduledJob
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.ScheduledJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.ScheduledJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobHistory provides a mock function with given fields: pipeline, stage, job, offset
+func (_m *Client) GetJobHistory(pipeline string, stage string, job string, offset int) ([]*gocd.JobHistory, error) {
+	ret := _m.Called(pipeline, stage, job, offset)
+
+	var r0 []*gocd.JobHistory
+	if rf, ok := ret.Get(0).(func(string, string, string, int) []*gocd.JobHistory); ok {
+		r0 = rf(pipeline, stage, job, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.JobHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, int) error); ok {
+		r1 = rf(pipeline, stage, job, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobHistoryContext provides a mock function with given fields: ctx, pipeline, stage, job, offset
+func (_m *Client) GetJobHistoryContext(ctx context.Context, pipeline string, stage string, job string, offset int) ([]*gocd.JobHistory, error) {
+	ret := _m.Called(ctx, pipeline, stage, job, offset)
+
+	var r0 []*gocd.JobHistory
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) []*gocd.JobHistory); ok {
+		r0 = rf(ctx, pipeline, stage, job, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.JobHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
+		r1 = rf(ctx, pipeline, stage, job, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IterateJobHistory provides a mock function with given fields: pipeline, stage, job, bounds
+func (_m *Client) IterateJobHistory(pipeline string, stage string, job string, bounds gocd.HistoryBounds) *gocd.JobHistoryIterator {
+	ret := _m.Called(pipeline, stage, job, bounds)
+
+	var r0 *gocd.JobHistoryIterator
+	if rf, ok := ret.Get(0).(func(string, string, string, gocd.HistoryBounds) *gocd.JobHistoryIterator); ok {
+		r0 = rf(pipeline, stage, job, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.JobHistoryIterator)
+		}
+	}
+
+	return r0
+}
+
+// GetJobConsoleLog provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, job
+func (_m *Client) GetJobConsoleLog(pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (io.ReadCloser, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, job)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string) io.ReadCloser); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobConsoleLogContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job
+func (_m *Client) GetJobConsoleLogContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string) io.ReadCloser); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, string) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TailJobConsoleLog provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job, startLine
+func (_m *Client) TailJobConsoleLog(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, startLine int) *gocd.ConsoleLogTail {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job, startLine)
+
+	var r0 *gocd.ConsoleLogTail
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string, int) *gocd.ConsoleLogTail); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, startLine)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.ConsoleLogTail)
+		}
+	}
+
+	return r0
+}
+
+// ListJobArtifacts provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, job
+func (_m *Client) ListJobArtifacts(pipeline string, pipelineCounter int, stage string, stageCounter int, job string) ([]*gocd.Artifact, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, job)
+
+	var r0 []*gocd.Artifact
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string) []*gocd.Artifact); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.Artifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobArtifactsContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job
+func (_m *Client) ListJobArtifactsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string) ([]*gocd.Artifact, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+
+	var r0 []*gocd.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string) []*gocd.Artifact); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.Artifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, string) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DownloadArtifact provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, job, artifactPath
+func (_m *Client) DownloadArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string) (io.ReadCloser, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string, string) io.ReadCloser); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string, string) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DownloadArtifactContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath
+func (_m *Client) DownloadArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string, string) io.ReadCloser); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, string, string) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DownloadArtifactFolder provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, job, artifactPath
+func (_m *Client) DownloadArtifactFolder(pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string) (io.ReadCloser, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string, string) io.ReadCloser); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string, string) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DownloadArtifactFolderContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath
+func (_m *Client) DownloadArtifactFolderContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string, string) io.ReadCloser); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, string, string) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadArtifact provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content
+func (_m *Client) UploadArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string, content io.Reader) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string, string, io.Reader) *gocd.SimpleMessage); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string, string, io.Reader) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadArtifactContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content
+func (_m *Client) UploadArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string, content io.Reader) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string, string, io.Reader) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, string, string, io.Reader) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AppendArtifact provides a mock function with given fields: pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content
+func (_m *Client) AppendArtifact(pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string, content io.Reader) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string, string, io.Reader) *gocd.SimpleMessage); ok {
+		r0 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string, string, io.Reader) error); ok {
+		r1 = rf(pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AppendArtifactContext provides a mock function with given fields: ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content
+func (_m *Client) AppendArtifactContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, job string, artifactPath string, content io.Reader) (*gocd.SimpleMessage, error) {
+	ret := _m.Called(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+
+	var r0 *gocd.SimpleMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int, string, string, io.Reader) *gocd.SimpleMessage); ok {
+		r0 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.SimpleMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int, string, string, io.Reader) error); ok {
+		r1 = rf(ctx, pipeline, pipelineCounter, stage, stageCounter, job, artifactPath, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllEnvironmentConfigs provides a mock function with given fields:
+func (_m *Client) GetAllEnvironmentConfigs() ([]*gocd.EnvironmentConfig, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func() []*gocd.EnvironmentConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllEnvironmentConfigsContext provides a mock function with given fields: ctx
+func (_m *Client) GetAllEnvironmentConfigsContext(ctx context.Context) ([]*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.EnvironmentConfig); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnvironmentConfig provides a mock function with given fields: name
+func (_m *Client) GetEnvironmentConfig(name string) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(name)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(string) *gocd.EnvironmentConfig); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnvironmentConfigContext provides a mock function with given fields: ctx, name
+func (_m *Client) GetEnvironmentConfigContext(ctx context.Context, name string) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocd.EnvironmentConfig); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEnvironmentConfig provides a mock function with given fields: environment
+func (_m *Client) CreateEnvironmentConfig(environment *gocd.EnvironmentConfig) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(environment)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(*gocd.EnvironmentConfig) *gocd.EnvironmentConfig); ok {
+		r0 = rf(environment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.EnvironmentConfig) error); ok {
+		r1 = rf(environment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEnvironmentConfigContext provides a mock function with given fields: ctx, environment
+func (_m *Client) CreateEnvironmentConfigContext(ctx context.Context, environment *gocd.EnvironmentConfig) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(ctx, environment)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.EnvironmentConfig) *gocd.EnvironmentConfig); ok {
+		r0 = rf(ctx, environment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.EnvironmentConfig) error); ok {
+		r1 = rf(ctx, environment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEnvironmentConfig provides a mock function with given fields: environment
+func (_m *Client) UpdateEnvironmentConfig(environment *gocd.EnvironmentConfig) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(environment)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(*gocd.EnvironmentConfig) *gocd.EnvironmentConfig); ok {
+		r0 = rf(environment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gocd.EnvironmentConfig) error); ok {
+		r1 = rf(environment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEnvironmentConfigContext provides a mock function with given fields: ctx, environment
+func (_m *Client) UpdateEnvironmentConfigContext(ctx context.Context, environment *gocd.EnvironmentConfig) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(ctx, environment)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(context.Context, *gocd.EnvironmentConfig) *gocd.EnvironmentConfig); ok {
+		r0 = rf(ctx, environment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gocd.EnvironmentConfig) error); ok {
+		r1 = rf(ctx, environment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchEnvironmentConfig provides a mock function with given fields: name, patch
+func (_m *Client) PatchEnvironmentConfig(name string, patch gocd.EnvironmentConfigPatch) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(name, patch)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(string, gocd.EnvironmentConfigPatch) *gocd.EnvironmentConfig); ok {
+		r0 = rf(name, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, gocd.EnvironmentConfigPatch) error); ok {
+		r1 = rf(name, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchEnvironmentConfigContext provides a mock function with given fields: ctx, name, patch
+func (_m *Client) PatchEnvironmentConfigContext(ctx context.Context, name string, patch gocd.EnvironmentConfigPatch) (*gocd.EnvironmentConfig, error) {
+	ret := _m.Called(ctx, name, patch)
+
+	var r0 *gocd.EnvironmentConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string, gocd.EnvironmentConfigPatch) *gocd.EnvironmentConfig); ok {
+		r0 = rf(ctx, name, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.EnvironmentConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, gocd.EnvironmentConfigPatch) error); ok {
+		r1 = rf(ctx, name, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteEnvironmentConfig provides a mock function with given fields: name
+func (_m *Client) DeleteEnvironmentConfig(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEnvironmentConfigContext provides a mock function with given fields: ctx, name
+func (_m *Client) DeleteEnvironmentConfigContext(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetServerHealthMessages provides a mock function with given fields:
+func (_m *Client) GetServerHealthMessages() ([]*gocd.ServerHealthMessage, error) {
+	ret := _m.Called()
+
+	var r0 []*gocd.ServerHealthMessage
+	if rf, ok := ret.Get(0).(func() []*gocd.ServerHealthMessage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.ServerHealthMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerHealthMessagesContext provides a mock function with given fields: ctx
+func (_m *Client) GetServerHealthMessagesContext(ctx context.Context) ([]*gocd.ServerHealthMessage, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*gocd.ServerHealthMessage
+	if rf, ok := ret.Get(0).(func(context.Context) []*gocd.ServerHealthMessage); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gocd.ServerHealthMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerVersion provides a mock function with given fields:
+func (_m *Client) GetServerVersion() (*gocd.ServerVersion, error) {
+	ret := _m.Called()
+
+	var r0 *gocd.ServerVersion
+	if rf, ok := ret.Get(0).(func() *gocd.ServerVersion); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.ServerVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerVersionContext provides a mock function with given fields: ctx
+func (_m *Client) GetServerVersionContext(ctx context.Context) (*gocd.ServerVersion, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *gocd.ServerVersion
+	if rf, ok := ret.Get(0).(func(context.Context) *gocd.ServerVersion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocd.ServerVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
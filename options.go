@@ -0,0 +1,99 @@
+package gocd
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures the DefaultClient built by NewWithOptions
+type Option func(*DefaultClient)
+
+// WithBasicAuth authenticates every request with the given username and password
+func WithBasicAuth(username, password string) Option {
+	return func(c *DefaultClient) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// TokenProvider returns the personal access token to authenticate a request
+// with. It is called before every request, so that it can refresh or rotate
+// the token without recreating the client.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// WithBearerToken authenticates every request with the given personal access
+// token (GoCD 19.2+) in an "Authorization: Bearer" header
+func WithBearerToken(token string) Option {
+	return WithTokenProvider(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithTokenProvider authenticates every request with the personal access token
+// returned by provider in an "Authorization: Bearer" header. It takes
+// precedence over WithBasicAuth.
+func WithTokenProvider(provider TokenProvider) Option {
+	return func(c *DefaultClient) {
+		c.tokenProvider = provider
+	}
+}
+
+// WithBasePath serves the API under the given path instead of DefaultBasePath,
+// e.g. when GoCD is mounted under a custom context path or behind a reverse
+// proxy prefix. Use an empty path when GoCD is served at the root of the host.
+func WithBasePath(basePath string) Option {
+	return func(c *DefaultClient) {
+		c.basePath = ""
+		if basePath = strings.Trim(basePath, "/"); basePath != "" {
+			c.basePath = "/" + basePath
+		}
+	}
+}
+
+// WithHTTPClient sends every request through the given http.Client, e.g. to
+// plug a custom http.RoundTripper. The client is used as is: WithTimeout,
+// WithTLSConfig and WithProxy have no effect when it is set, and its Timeout
+// bounds the streamed transfers (artifacts, console logs) too.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *DefaultClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout overrides DefaultTimeout for connecting to the GoCD server and
+// exchanging a request with it. The streamed transfers (artifacts, console
+// logs) are only bounded by it until GoCD answers, the transfer itself is
+// bounded by the context of the call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *DefaultClient) {
+		c.timeout = timeout
+	}
+}
+
+// WithTLSConfig uses the given TLS configuration to reach the GoCD server, e.g.
+// to trust a corporate CA bundle or to present a client certificate
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *DefaultClient) {
+		c.tlsConfig = config
+	}
+}
+
+// WithProxy selects the proxy to reach the GoCD server with, e.g.
+// http.ProxyURL(proxyURL). The proxy is taken from the environment
+// (http.ProxyFromEnvironment) by default, give nil to always connect directly.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(c *DefaultClient) {
+		c.proxy = proxy
+	}
+}
+
+// WithUserAgent sends the given User-Agent header along with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *DefaultClient) {
+		c.userAgent = userAgent
+	}
+}
@@ -0,0 +1,105 @@
+package gocd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingTransport struct {
+	calls int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewWithOptions(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BasicAuthCheck(t, r)
+		assert.Equal(t, "go-gocd-test/1.0", r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client := NewWithOptions(server.URL,
+		WithBasicAuth(testUsername, testPassword),
+		WithUserAgent("go-gocd-test/1.0"),
+		WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	status, err := client.GetPipelineStatus("pipeline1")
+	assert.NoError(t, err)
+	assert.True(t, status.Paused)
+	assert.Equal(t, 1, transport.calls)
+}
+
+func TestNewWithTLSConfig(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL, testUsername, testPassword).GetPipelineStatus("pipeline1")
+	assert.Error(t, err, "the test server certificate should not be trusted by default")
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+	client := NewWithOptions(server.URL, WithTLSConfig(&tls.Config{RootCAs: certPool}))
+	status, err := client.GetPipelineStatus("pipeline1")
+	assert.NoError(t, err)
+	assert.True(t, status.Paused)
+}
+
+func TestNewWithTokenProvider(t *testing.T) {
+	t.Parallel()
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, hasBasicAuth := r.BasicAuth()
+		assert.False(t, hasBasicAuth)
+		received = append(received, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	tokens := []string{"token-1", "token-2"}
+	calls := 0
+	client := NewWithOptions(server.URL, WithBasicAuth(testUsername, testPassword), WithTokenProvider(func(context.Context) (string, error) {
+		if calls >= len(tokens) {
+			return "", errors.New("no more tokens")
+		}
+		calls++
+		return tokens[calls-1], nil
+	}))
+	for range tokens {
+		_, err := client.GetPipelineStatus("pipeline1")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, received)
+
+	_, err := client.GetPipelineStatus("pipeline1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no more tokens")
+	assert.Equal(t, 2, len(received))
+}
+
+func TestNewWithBearerToken(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer my-access-token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	_, err := NewWithOptions(server.URL, WithBearerToken("my-access-token")).GetPipelineStatus("pipeline1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,301 @@
+package gocd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// PipelineConfig is the definition of a pipeline, as managed through the
+// pipeline config API
+type PipelineConfig struct {
+	Name                 string                `json:"name"`
+	LabelTemplate        string                `json:"label_template,omitempty"`
+	LockBehavior         string                `json:"lock_behavior,omitempty"`
+	Origin               *ConfigOrigin         `json:"origin,omitempty"`
+	Parameters           []PipelineParameter   `json:"parameters"`
+	EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
+	Materials            []MaterialConfig      `json:"materials"`
+	// Template is the name of the template the pipeline gets its stages
+	// from, if any. Stages must be empty then.
+	Template     string        `json:"template,omitempty"`
+	Stages       []StageConfig `json:"stages,omitempty"`
+	TrackingTool *TrackingTool `json:"tracking_tool,omitempty"`
+	Timer        *TimerConfig  `json:"timer,omitempty"`
+
+	// ETag identifies the version of the config GoCD sent, it's required to
+	// update it. See UpdatePipelineConfig.
+	ETag string `json:"-"`
+}
+
+// ConfigOrigin tells where a config is defined: "gocd" for the main config, or
+// "config_repo" for a config repository
+type ConfigOrigin struct {
+	Type string `json:"type"`
+	File string `json:"file,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+// PipelineParameter is a parameter of a pipeline, referenced as #{name} in its
+// config
+type PipelineParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MaterialConfig is a material of a pipeline config
+type MaterialConfig struct {
+	// Type is one of "git", "svn", "hg", "p4", "tfs", "dependency",
+	// "package" or "plugin"
+	Type       string             `json:"type"`
+	Attributes MaterialAttributes `json:"attributes"`
+}
+
+// MaterialAttributes are the attributes of a material config. Only the ones
+// relevant to the type of the material are set.
+type MaterialAttributes struct {
+	Name         string          `json:"name,omitempty"`
+	AutoUpdate   bool            `json:"auto_update"`
+	Destination  string          `json:"destination,omitempty"`
+	Filter       *MaterialFilter `json:"filter,omitempty"`
+	InvertFilter bool            `json:"invert_filter,omitempty"`
+
+	// scm materials
+	URL               string `json:"url,omitempty"`
+	Username          string `json:"username,omitempty"`
+	Password          string `json:"password,omitempty"`
+	EncryptedPassword string `json:"encrypted_password,omitempty"`
+	// git
+	Branch          string `json:"branch,omitempty"`
+	SubmoduleFolder string `json:"submodule_folder,omitempty"`
+	ShallowClone    bool   `json:"shallow_clone,omitempty"`
+	// svn
+	CheckExternals bool `json:"check_externals,omitempty"`
+	// p4
+	Port       string `json:"port,omitempty"`
+	UseTickets bool   `json:"use_tickets,omitempty"`
+	View       string `json:"view,omitempty"`
+	// tfs
+	Domain      string `json:"domain,omitempty"`
+	ProjectPath string `json:"project_path,omitempty"`
+	// dependency
+	Pipeline string `json:"pipeline,omitempty"`
+	Stage    string `json:"stage,omitempty"`
+	// package and plugin, the id of the package or scm
+	Ref string `json:"ref,omitempty"`
+}
+
+// MaterialFilter lists the files whose changes don't trigger the pipeline
+type MaterialFilter struct {
+	Ignore []string `json:"ignore"`
+}
+
+// StageConfig is a stage of a pipeline config or template
+type StageConfig struct {
+	Name                  string                `json:"name"`
+	FetchMaterials        bool                  `json:"fetch_materials"`
+	CleanWorkingDirectory bool                  `json:"clean_working_directory"`
+	NeverCleanupArtifacts bool                  `json:"never_cleanup_artifacts"`
+	Approval              *StageApproval        `json:"approval,omitempty"`
+	EnvironmentVariables  []EnvironmentVariable `json:"environment_variables"`
+	Jobs                  []JobConfig           `json:"jobs"`
+}
+
+// StageApproval tells how a stage gets triggered
+type StageApproval struct {
+	// Type is "success" to run the stage once the previous one passed, or
+	// "manual"
+	Type          string             `json:"type"`
+	Authorization StageAuthorization `json:"authorization"`
+}
+
+// StageAuthorization lists who may trigger a stage requiring a manual approval
+type StageAuthorization struct {
+	Roles []string `json:"roles"`
+	Users []string `json:"users"`
+}
+
+// JobConfig is a job of a stage config
+type JobConfig struct {
+	Name string `json:"name"`
+	// RunInstanceCount is nil to run the job once, a number of instances, or
+	// "all" to run it on all the agents
+	RunInstanceCount interface{} `json:"run_instance_count"`
+	// Timeout is nil for the server's default, a number of minutes without
+	// output, or "never"
+	Timeout              interface{}           `json:"timeout"`
+	EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
+	Resources            []string              `json:"resources"`
+	ElasticProfileID     string                `json:"elastic_profile_id,omitempty"`
+	Tasks                []TaskConfig          `json:"tasks"`
+	Tabs                 []JobTab              `json:"tabs"`
+	Artifacts            []ArtifactConfig      `json:"artifacts"`
+	Properties           []JobProperty         `json:"properties"`
+}
+
+// TaskConfig is a task of a job config
+type TaskConfig struct {
+	// Type is one of "exec", "ant", "nant", "rake", "fetch" or
+	// "pluggable_task"
+	Type       string         `json:"type"`
+	Attributes TaskAttributes `json:"attributes"`
+}
+
+// TaskAttributes are the attributes of a task config. Only the ones relevant
+// to the type of the task are set.
+type TaskAttributes struct {
+	// RunIf lists when the task runs: "passed", "failed" and/or "any"
+	RunIf            []string    `json:"run_if,omitempty"`
+	OnCancel         *TaskConfig `json:"on_cancel,omitempty"`
+	WorkingDirectory string      `json:"working_directory,omitempty"`
+	// exec
+	Command   string   `json:"command,omitempty"`
+	Arguments []string `json:"arguments,omitempty"`
+	// ant, nant and rake
+	BuildFile string `json:"build_file,omitempty"`
+	Target    string `json:"target,omitempty"`
+	NantPath  string `json:"nant_path,omitempty"`
+	// fetch
+	ArtifactOrigin string `json:"artifact_origin,omitempty"`
+	Pipeline       string `json:"pipeline,omitempty"`
+	Stage          string `json:"stage,omitempty"`
+	Job            string `json:"job,omitempty"`
+	Source         string `json:"source,omitempty"`
+	IsSourceAFile  bool   `json:"is_source_a_file,omitempty"`
+	Destination    string `json:"destination,omitempty"`
+	// pluggable_task
+	PluginConfiguration *PluginConfiguration `json:"plugin_configuration,omitempty"`
+	Configuration       []ConfigProperty     `json:"configuration,omitempty"`
+}
+
+// PluginConfiguration identifies the plugin of a pluggable task
+type PluginConfiguration struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// ConfigProperty is a key/value setting of a plugin
+type ConfigProperty struct {
+	Key            string `json:"key"`
+	Value          string `json:"value,omitempty"`
+	EncryptedValue string `json:"encrypted_value,omitempty"`
+}
+
+// JobTab is a custom tab displaying an artifact of a job
+type JobTab struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ArtifactConfig is an artifact published by a job
+type ArtifactConfig struct {
+	// Type is "build", "test" or "external"
+	Type        string `json:"type"`
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	// external artifacts
+	ID            string           `json:"id,omitempty"`
+	StoreID       string           `json:"store_id,omitempty"`
+	Configuration []ConfigProperty `json:"configuration,omitempty"`
+}
+
+// JobProperty is a property of a job, read from an XML artifact
+type JobProperty struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	XPath  string `json:"xpath"`
+}
+
+// TrackingTool links the commit messages to an issue tracker
+type TrackingTool struct {
+	// Type is "generic" or "mingle"
+	Type       string                 `json:"type"`
+	Attributes TrackingToolAttributes `json:"attributes"`
+}
+
+// TrackingToolAttributes are the attributes of a generic tracking tool
+type TrackingToolAttributes struct {
+	URLPattern string `json:"url_pattern"`
+	Regex      string `json:"regex"`
+}
+
+// TimerConfig schedules a pipeline periodically
+type TimerConfig struct {
+	// Spec is a cron-like specification, e.g. "0 0 22 ? * MON-FRI"
+	Spec          string `json:"spec"`
+	OnlyOnChanges bool   `json:"only_on_changes"`
+}
+
+// GetPipelineConfig returns the config of the given pipeline, along with its
+// ETag
+func (c *DefaultClient) GetPipelineConfig(name string) (*PipelineConfig, error) {
+	return c.GetPipelineConfigContext(context.Background(), name)
+}
+
+// GetPipelineConfigContext is the same as GetPipelineConfig, bound to the given context
+func (c *DefaultClient) GetPipelineConfigContext(ctx context.Context, name string) (*PipelineConfig, error) {
+	res := new(PipelineConfig)
+	headers := c.negotiate(ctx, apiPipelineConfig).headers()
+	resp, err := c.doResponse(ctx, http.MethodGet, fmt.Sprintf("/api/admin/pipelines/%s", url.PathEscape(name)), headers, nil, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// CreatePipelineConfig creates a pipeline in the given pipeline group.
+// Returns the created config
+func (c *DefaultClient) CreatePipelineConfig(group string, config *PipelineConfig) (*PipelineConfig, error) {
+	return c.CreatePipelineConfigContext(context.Background(), group, config)
+}
+
+// CreatePipelineConfigContext is the same as CreatePipelineConfig, bound to the given context
+func (c *DefaultClient) CreatePipelineConfigContext(ctx context.Context, group string, config *PipelineConfig) (*PipelineConfig, error) {
+	data := struct {
+		Group    string          `json:"group"`
+		Pipeline *PipelineConfig `json:"pipeline"`
+	}{group, config}
+	res := new(PipelineConfig)
+	headers := c.negotiate(ctx, apiPipelineConfig).headers()
+	resp, err := c.doResponse(ctx, http.MethodPost, "/api/admin/pipelines", headers, data, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// UpdatePipelineConfig replaces the config of a pipeline with the given one,
+// provided it's still the version identified by config.ETag: see IsConflict
+// otherwise. Returns the updated config, along with its new ETag
+func (c *DefaultClient) UpdatePipelineConfig(config *PipelineConfig) (*PipelineConfig, error) {
+	return c.UpdatePipelineConfigContext(context.Background(), config)
+}
+
+// UpdatePipelineConfigContext is the same as UpdatePipelineConfig, bound to the given context
+func (c *DefaultClient) UpdatePipelineConfigContext(ctx context.Context, config *PipelineConfig) (*PipelineConfig, error) {
+	res := new(PipelineConfig)
+	headers, err := ifMatch(c.negotiate(ctx, apiPipelineConfig).headers(), config.ETag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doResponse(ctx, http.MethodPut, fmt.Sprintf("/api/admin/pipelines/%s", url.PathEscape(config.Name)), headers, config, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// DeletePipelineConfig deletes the given pipeline
+func (c *DefaultClient) DeletePipelineConfig(name string) error {
+	return c.DeletePipelineConfigContext(context.Background(), name)
+}
+
+// DeletePipelineConfigContext is the same as DeletePipelineConfig, bound to the given context
+func (c *DefaultClient) DeletePipelineConfigContext(ctx context.Context, name string) error {
+	headers := c.negotiate(ctx, apiPipelineConfig).headers()
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/admin/pipelines/%s", url.PathEscape(name)), headers, nil, nil)
+}
@@ -0,0 +1,148 @@
+package gocd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPipelineConfigETag = `"05548388f7ef5042cd39f7fe42e85735"`
+
+func TestGetPipelineConfig(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipelines/my_pipeline", serveWithETag(testPipelineConfigETag, serveFileAsJSON(t, "GET", "test-fixtures/get_pipeline_config.json", 6, DummyRequestBodyValidator)))
+	defer server.Close()
+	config, err := client.GetPipelineConfig("my_pipeline")
+	assert.NoError(t, err)
+	assert.Equal(t, testPipelineConfigETag, config.ETag)
+	assert.Equal(t, "my_pipeline", config.Name)
+	assert.Equal(t, "${COUNT}", config.LabelTemplate)
+	assert.Equal(t, "lockOnFailure", config.LockBehavior)
+	assert.Equal(t, "", config.Template)
+	assert.Equal(t, "gocd", config.Origin.Type)
+	assert.Equal(t, []PipelineParameter{{Name: "ENV", Value: "staging"}}, config.Parameters)
+	assert.Equal(t, EnvironmentVariable{Secure: true, Name: "PASSWORD", EncryptedValue: "1f3rrs9uhn63hd"}, config.EnvironmentVariables[1])
+
+	assert.Equal(t, 2, len(config.Materials))
+	git := config.Materials[0]
+	assert.Equal(t, "git", git.Type)
+	assert.Equal(t, "https://github.com/gocd/gocd", git.Attributes.URL)
+	assert.Equal(t, "master", git.Attributes.Branch)
+	assert.True(t, git.Attributes.ShallowClone)
+	assert.Equal(t, []string{"docs/**"}, git.Attributes.Filter.Ignore)
+	dependency := config.Materials[1]
+	assert.Equal(t, "dependency", dependency.Type)
+	assert.Equal(t, "upstream", dependency.Attributes.Pipeline)
+	assert.Equal(t, "package", dependency.Attributes.Stage)
+
+	assert.Equal(t, 1, len(config.Stages))
+	stage := config.Stages[0]
+	assert.Equal(t, "defaultStage", stage.Name)
+	assert.Equal(t, "success", stage.Approval.Type)
+	job := stage.Jobs[0]
+	assert.Equal(t, "defaultJob", job.Name)
+	assert.Nil(t, job.RunInstanceCount)
+	assert.Equal(t, "never", job.Timeout)
+	assert.Equal(t, []string{"linux"}, job.Resources)
+	assert.Equal(t, 2, len(job.Tasks))
+	assert.Equal(t, "exec", job.Tasks[0].Type)
+	assert.Equal(t, "make", job.Tasks[0].Attributes.Command)
+	assert.Equal(t, []string{"test"}, job.Tasks[0].Attributes.Arguments)
+	assert.Equal(t, "cleanup.sh", job.Tasks[0].Attributes.OnCancel.Attributes.Command)
+	assert.Equal(t, "fetch", job.Tasks[1].Type)
+	assert.Equal(t, "dist/app.tar.gz", job.Tasks[1].Attributes.Source)
+	assert.True(t, job.Tasks[1].Attributes.IsSourceAFile)
+	assert.Equal(t, []JobTab{{Name: "coverage", Path: "coverage/index.html"}}, job.Tabs)
+	assert.Equal(t, []ArtifactConfig{{Type: "build", Source: "target/*.jar", Destination: "jars"}}, job.Artifacts)
+
+	assert.Equal(t, "https://github.com/gocd/gocd/issues/${ID}", config.TrackingTool.Attributes.URLPattern)
+	assert.Equal(t, &TimerConfig{Spec: "0 0 22 ? * MON-FRI", OnlyOnChanges: true}, config.Timer)
+}
+
+func TestCreatePipelineConfig(t *testing.T) {
+	t.Parallel()
+	requestBodyValidator := func(body string) error {
+		var data struct {
+			Group    string          `json:"group"`
+			Pipeline *PipelineConfig `json:"pipeline"`
+		}
+		if err := json.Unmarshal([]byte(body), &data); err != nil {
+			return err
+		}
+		assert.Equal(t, "first", data.Group)
+		assert.Equal(t, "my_pipeline", data.Pipeline.Name)
+		assert.Equal(t, "make", data.Pipeline.Stages[0].Jobs[0].Tasks[0].Attributes.Command)
+		return nil
+	}
+	client, server := newTestAPIClient("/go/api/admin/pipelines", serveWithETag(testPipelineConfigETag, serveFileAsJSON(t, "POST", "test-fixtures/get_pipeline_config.json", 6, requestBodyValidator)))
+	defer server.Close()
+	config, err := client.CreatePipelineConfig("first", &PipelineConfig{
+		Name: "my_pipeline",
+		Materials: []MaterialConfig{
+			{Type: "git", Attributes: MaterialAttributes{URL: "https://github.com/gocd/gocd", AutoUpdate: true}},
+		},
+		Stages: []StageConfig{{
+			Name: "defaultStage",
+			Jobs: []JobConfig{{
+				Name:  "defaultJob",
+				Tasks: []TaskConfig{{Type: "exec", Attributes: TaskAttributes{Command: "make", Arguments: []string{"test"}}}},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "my_pipeline", config.Name)
+	assert.Equal(t, testPipelineConfigETag, config.ETag)
+}
+
+func TestUpdatePipelineConfig(t *testing.T) {
+	t.Parallel()
+	const newETag = `"8a3bd4d5a2e0c8f9a4b3e1f2d2c1b0a9"`
+	client, server := newTestAPIClient("/go/api/admin/pipelines/my_pipeline", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "PUT")
+		if r.Header.Get("If-Match") != testPipelineConfigETag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"message": "Someone has modified the configuration for pipeline 'my_pipeline'. Please update your copy of the config with the changes."}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var config PipelineConfig
+		assert.NoError(t, json.Unmarshal(body, &config))
+		assert.Equal(t, "${COUNT}-${git[:8]}", config.LabelTemplate)
+		w.Header().Set("ETag", newETag)
+		w.Write(body)
+	})
+	defer server.Close()
+
+	config := &PipelineConfig{Name: "my_pipeline", LabelTemplate: "${COUNT}-${git[:8]}", ETag: testPipelineConfigETag}
+	updated, err := client.UpdatePipelineConfig(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "${COUNT}-${git[:8]}", updated.LabelTemplate)
+	assert.Equal(t, newETag, updated.ETag)
+
+	// the config changed since
+	_, err = client.UpdatePipelineConfig(updated)
+	assert.True(t, IsConflict(err))
+	assert.Contains(t, err.Error(), "Someone has modified the configuration")
+}
+
+func TestUpdatePipelineConfigWithoutETag(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipelines/my_pipeline", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s without an ETag", r.Method)
+	})
+	defer server.Close()
+
+	_, err := client.UpdatePipelineConfig(&PipelineConfig{Name: "my_pipeline"})
+	assert.Equal(t, ErrMissingETag, err)
+}
+
+func TestDeletePipelineConfig(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipelines/my_pipeline", serveFileAsJSON(t, "DELETE", "test-fixtures/delete_pipeline_config.json", 6, DummyRequestBodyValidator))
+	defer server.Close()
+	err := client.DeletePipelineConfig("my_pipeline")
+	assert.NoError(t, err)
+}
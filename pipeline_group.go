@@ -1,9 +1,8 @@
 package gocd
 
 import (
-	"encoding/json"
-
-	multierror "github.com/hashicorp/go-multierror"
+	"context"
+	"net/http"
 )
 
 // Pipeline Object
@@ -22,19 +21,11 @@ type PipelineGroup struct {
 
 // GetPipelineGroups List pipeline groups along with the pipelines, stages and materials for each pipeline.
 func (c *DefaultClient) GetPipelineGroups() ([]*PipelineGroup, error) {
-	var errors *multierror.Error
-
-	// Somehow GoCD will return "The resource you requested was not found!" if you specify an Accept header
-	_, body, errs := c.Request.
-		Get(c.resolve("/go/api/config/pipeline_groups")).
-		//Set("Accept", "application/vnd.go.cd.v2+json").
-		End()
-
-	if errs != nil {
-		errors = multierror.Append(errors, errs...)
-		return []*PipelineGroup{}, errors.ErrorOrNil()
-	}
+	return c.GetPipelineGroupsContext(context.Background())
+}
 
+// GetPipelineGroupsContext Same as GetPipelineGroups, bound to the given context
+func (c *DefaultClient) GetPipelineGroupsContext(ctx context.Context) ([]*PipelineGroup, error) {
 	// first parse the json into temporary structure, so we parse stages object
 	// with a single name string attribute as simple string
 	type tmpStage struct {
@@ -53,10 +44,9 @@ func (c *DefaultClient) GetPipelineGroups() ([]*PipelineGroup, error) {
 	}
 	var tmpPipelineGroups []tmpPipelineGroup
 
-	jsonErr := json.Unmarshal([]byte(body), &tmpPipelineGroups)
-	if jsonErr != nil {
-		errors = multierror.Append(errors, jsonErr)
-		return []*PipelineGroup{}, errors.ErrorOrNil()
+	// Somehow GoCD will return "The resource you requested was not found!" if you specify an Accept header
+	if err := c.do(ctx, http.MethodGet, "/api/config/pipeline_groups", nil, nil, &tmpPipelineGroups); err != nil {
+		return []*PipelineGroup{}, err
 	}
 	// create the good pipeline groups structures and copy data from the temporary structs
 	pipelineGroups := make([]*PipelineGroup, len(tmpPipelineGroups))
@@ -71,5 +61,5 @@ func (c *DefaultClient) GetPipelineGroups() ([]*PipelineGroup, error) {
 		}
 
 	}
-	return pipelineGroups, errors.ErrorOrNil()
+	return pipelineGroups, nil
 }
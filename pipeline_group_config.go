@@ -0,0 +1,157 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// PipelineGroupConfig is the definition of a pipeline group, as managed through
+// the pipeline group config API
+type PipelineGroupConfig struct {
+	Name          string                      `json:"name"`
+	Authorization *PipelineGroupAuthorization `json:"authorization,omitempty"`
+
+	// Pipelines lists the names of the pipelines of the group. It's ignored
+	// when creating or updating the group, see CreatePipelineConfig.
+	Pipelines []string `json:"-"`
+	// ETag identifies the version of the group GoCD sent, it's required to
+	// update it. See UpdatePipelineGroupConfig.
+	ETag string `json:"-"`
+}
+
+// PipelineGroupAuthorization lists who may view, operate and administer the
+// pipelines of a group. A group without any authorization is open to all the
+// users.
+type PipelineGroupAuthorization struct {
+	View    PipelineGroupPermission `json:"view"`
+	Operate PipelineGroupPermission `json:"operate"`
+	Admins  PipelineGroupPermission `json:"admins"`
+}
+
+// PipelineGroupPermission lists the users and roles granted a permission on a
+// pipeline group
+type PipelineGroupPermission struct {
+	Users []string `json:"users"`
+	Roles []string `json:"roles"`
+}
+
+// MarshalJSON sends the missing users or roles as empty lists
+func (p PipelineGroupPermission) MarshalJSON() ([]byte, error) {
+	type tmpPermission PipelineGroupPermission
+	return json.Marshal(tmpPermission{Users: nonNil(p.Users), Roles: nonNil(p.Roles)})
+}
+
+// UnmarshalJSON flattens the pipelines of the group to their names
+func (pg *PipelineGroupConfig) UnmarshalJSON(b []byte) error {
+	type tmpGroup struct {
+		Name          string                      `json:"name"`
+		Authorization *PipelineGroupAuthorization `json:"authorization,omitempty"`
+		Pipelines     []environmentPipeline       `json:"pipelines"`
+	}
+	var tmp tmpGroup
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+	pg.Name = tmp.Name
+	pg.Authorization = tmp.Authorization
+	pg.Pipelines = make([]string, len(tmp.Pipelines))
+	for i, p := range tmp.Pipelines {
+		pg.Pipelines[i] = p.Name
+	}
+	return nil
+}
+
+// GetAllPipelineGroupConfigs lists the pipeline groups, along with their
+// authorization and the names of their pipelines
+func (c *DefaultClient) GetAllPipelineGroupConfigs() ([]*PipelineGroupConfig, error) {
+	return c.GetAllPipelineGroupConfigsContext(context.Background())
+}
+
+// GetAllPipelineGroupConfigsContext is the same as GetAllPipelineGroupConfigs, bound to the given context
+func (c *DefaultClient) GetAllPipelineGroupConfigsContext(ctx context.Context) ([]*PipelineGroupConfig, error) {
+	type AllPipelineGroupsResponse struct {
+		Embedded struct {
+			Groups []*PipelineGroupConfig `json:"groups"`
+		} `json:"_embedded"`
+	}
+	var responseFormat AllPipelineGroupsResponse
+
+	headers := c.negotiate(ctx, apiPipelineGroups).headers()
+	if err := c.do(ctx, http.MethodGet, "/api/admin/pipeline_groups", headers, nil, &responseFormat); err != nil {
+		return []*PipelineGroupConfig{}, err
+	}
+	return responseFormat.Embedded.Groups, nil
+}
+
+// GetPipelineGroupConfig returns the given pipeline group, along with its ETag
+func (c *DefaultClient) GetPipelineGroupConfig(name string) (*PipelineGroupConfig, error) {
+	return c.GetPipelineGroupConfigContext(context.Background(), name)
+}
+
+// GetPipelineGroupConfigContext is the same as GetPipelineGroupConfig, bound to the given context
+func (c *DefaultClient) GetPipelineGroupConfigContext(ctx context.Context, name string) (*PipelineGroupConfig, error) {
+	res := new(PipelineGroupConfig)
+	headers := c.negotiate(ctx, apiPipelineGroups).headers()
+	resp, err := c.doResponse(ctx, http.MethodGet, fmt.Sprintf("/api/admin/pipeline_groups/%s", url.PathEscape(name)), headers, nil, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// CreatePipelineGroupConfig creates an empty pipeline group.
+// Returns the created group
+func (c *DefaultClient) CreatePipelineGroupConfig(group *PipelineGroupConfig) (*PipelineGroupConfig, error) {
+	return c.CreatePipelineGroupConfigContext(context.Background(), group)
+}
+
+// CreatePipelineGroupConfigContext is the same as CreatePipelineGroupConfig, bound to the given context
+func (c *DefaultClient) CreatePipelineGroupConfigContext(ctx context.Context, group *PipelineGroupConfig) (*PipelineGroupConfig, error) {
+	res := new(PipelineGroupConfig)
+	headers := c.negotiate(ctx, apiPipelineGroups).headers()
+	resp, err := c.doResponse(ctx, http.MethodPost, "/api/admin/pipeline_groups", headers, group, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// UpdatePipelineGroupConfig replaces the authorization of the pipeline group
+// named group.Name with the given one, provided it's still the version
+// identified by group.ETag: see IsConflict otherwise. A group can't be renamed
+// this way. Returns the updated group, along with its new ETag
+func (c *DefaultClient) UpdatePipelineGroupConfig(group *PipelineGroupConfig) (*PipelineGroupConfig, error) {
+	return c.UpdatePipelineGroupConfigContext(context.Background(), group)
+}
+
+// UpdatePipelineGroupConfigContext is the same as UpdatePipelineGroupConfig, bound to the given context
+func (c *DefaultClient) UpdatePipelineGroupConfigContext(ctx context.Context, group *PipelineGroupConfig) (*PipelineGroupConfig, error) {
+	res := new(PipelineGroupConfig)
+	headers, err := ifMatch(c.negotiate(ctx, apiPipelineGroups).headers(), group.ETag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doResponse(ctx, http.MethodPut, fmt.Sprintf("/api/admin/pipeline_groups/%s", url.PathEscape(group.Name)), headers, group, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// DeletePipelineGroupConfig deletes the given pipeline group, which must be
+// empty
+func (c *DefaultClient) DeletePipelineGroupConfig(name string) error {
+	return c.DeletePipelineGroupConfigContext(context.Background(), name)
+}
+
+// DeletePipelineGroupConfigContext is the same as DeletePipelineGroupConfig, bound to the given context
+func (c *DefaultClient) DeletePipelineGroupConfigContext(ctx context.Context, name string) error {
+	headers := c.negotiate(ctx, apiPipelineGroups).headers()
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/admin/pipeline_groups/%s", url.PathEscape(name)), headers, nil, nil)
+}
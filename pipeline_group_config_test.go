@@ -0,0 +1,116 @@
+package gocd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPipelineGroupETag = `"a8d5f23c7e2b4d1fa09b6c3e8f7d2a41"`
+
+func TestGetAllPipelineGroupConfigs(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipeline_groups", serveFileAsJSON(t, "GET", "test-fixtures/get_all_pipeline_group_configs.json", 1, DummyRequestBodyValidator))
+	defer server.Close()
+	groups, err := client.GetAllPipelineGroupConfigs()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, "first", groups[0].Name)
+	assert.Equal(t, []string{"up42"}, groups[0].Pipelines)
+	assert.Equal(t, []string{"developers"}, groups[0].Authorization.Operate.Roles)
+	assert.Equal(t, "second", groups[1].Name)
+	assert.Nil(t, groups[1].Authorization)
+	assert.Empty(t, groups[1].Pipelines)
+}
+
+func TestGetPipelineGroupConfig(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipeline_groups/first", serveWithETag(testPipelineGroupETag, serveFileAsJSON(t, "GET", "test-fixtures/get_pipeline_group_config.json", 1, DummyRequestBodyValidator)))
+	defer server.Close()
+	group, err := client.GetPipelineGroupConfig("first")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", group.Name)
+	assert.Equal(t, testPipelineGroupETag, group.ETag)
+	assert.Equal(t, []string{"up42"}, group.Pipelines)
+	assert.Equal(t, &PipelineGroupAuthorization{
+		View:    PipelineGroupPermission{Users: []string{"operate"}, Roles: []string{}},
+		Operate: PipelineGroupPermission{Users: []string{}, Roles: []string{"developers"}},
+		Admins:  PipelineGroupPermission{Users: []string{"operate"}, Roles: []string{"admins"}},
+	}, group.Authorization)
+}
+
+func TestCreatePipelineGroupConfig(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"name":"third","authorization":{"view":{"users":[],"roles":["developers"]},"operate":{"users":[],"roles":[]},"admins":{"users":["alice"],"roles":[]}}}`
+	client, server := newTestAPIClient("/go/api/admin/pipeline_groups", serveWithETag(testPipelineGroupETag, serveFileAsJSON(t, "POST", "test-fixtures/create_pipeline_group_config.json", 1, jsonBodyValidator(expectedBody))))
+	defer server.Close()
+	group, err := client.CreatePipelineGroupConfig(&PipelineGroupConfig{
+		Name: "third",
+		Authorization: &PipelineGroupAuthorization{
+			View:   PipelineGroupPermission{Users: []string{}, Roles: []string{"developers"}},
+			Admins: PipelineGroupPermission{Users: []string{"alice"}, Roles: []string{}},
+		},
+		// not sent
+		Pipelines: []string{"up42"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "third", group.Name)
+	assert.Equal(t, []string{"alice"}, group.Authorization.Admins.Users)
+	assert.Equal(t, testPipelineGroupETag, group.ETag)
+}
+
+func TestUpdatePipelineGroupConfig(t *testing.T) {
+	t.Parallel()
+	const newETag = `"4c2b9e1d0f8a7e6d5c4b3a2918f7e6d5"`
+	client, server := newTestAPIClient("/go/api/admin/pipeline_groups/first", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "PUT")
+		if r.Header.Get("If-Match") != testPipelineGroupETag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"message": "Someone has modified the configuration for pipeline group 'first'. Please update your copy of the config with the changes."}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var group PipelineGroupConfig
+		assert.NoError(t, json.Unmarshal(body, &group))
+		assert.Equal(t, []string{"bob"}, group.Authorization.Operate.Users)
+		w.Header().Set("ETag", newETag)
+		w.Write(body)
+	})
+	defer server.Close()
+
+	group := &PipelineGroupConfig{
+		Name:          "first",
+		Authorization: &PipelineGroupAuthorization{Operate: PipelineGroupPermission{Users: []string{"bob"}}},
+		ETag:          testPipelineGroupETag,
+	}
+	updated, err := client.UpdatePipelineGroupConfig(group)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, updated.Authorization.Operate.Users)
+	assert.Equal(t, newETag, updated.ETag)
+
+	// the group changed since
+	_, err = client.UpdatePipelineGroupConfig(updated)
+	assert.True(t, IsConflict(err))
+}
+
+func TestUpdatePipelineGroupConfigWithoutETag(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipeline_groups/first", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s without an ETag", r.Method)
+	})
+	defer server.Close()
+
+	_, err := client.UpdatePipelineGroupConfig(&PipelineGroupConfig{Name: "first"})
+	assert.Equal(t, ErrMissingETag, err)
+}
+
+func TestDeletePipelineGroupConfig(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/pipeline_groups/first", serveFileAsJSON(t, "DELETE", "test-fixtures/delete_pipeline_group_config.json", 1, DummyRequestBodyValidator))
+	defer server.Close()
+	err := client.DeletePipelineGroupConfig("first")
+	assert.NoError(t, err)
+}
@@ -1,7 +1,11 @@
 package gocd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 )
 
 // PipelineInstance represents a pipeline instance (for a given run)
@@ -44,8 +48,14 @@ type PipelineStatus struct {
 // GetPipelineInstance returns the pipeline instance corresponding to the given
 // pipeline name and counter
 func (c *DefaultClient) GetPipelineInstance(name string, counter int) (*PipelineInstance, error) {
+	return c.GetPipelineInstanceContext(context.Background(), name, counter)
+}
+
+// GetPipelineInstanceContext is the same as GetPipelineInstance, bound to the given context
+func (c *DefaultClient) GetPipelineInstanceContext(ctx context.Context, name string, counter int) (*PipelineInstance, error) {
 	res := new(PipelineInstance)
-	err := c.getJSON(fmt.Sprintf("/go/api/pipelines/%s/instance/%d", name, counter), nil, res)
+	headers := c.negotiate(ctx, apiPipelineInstance).headers()
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/pipelines/%s/instance/%d", url.PathEscape(name), counter), headers, nil, res)
 	return res, err
 }
 
@@ -55,37 +65,57 @@ func (c *DefaultClient) GetPipelineInstance(name string, counter int) (*Pipeline
 // setting an offset to 1 will skip the last run of the pipeline and will give
 // you a page of pipeline runs history which is 10 by default.
 func (c *DefaultClient) GetPipelineHistoryPage(name string, offset int) (*PipelineHistoryPage, error) {
+	return c.GetPipelineHistoryPageContext(context.Background(), name, offset)
+}
+
+// GetPipelineHistoryPageContext is the same as GetPipelineHistoryPage, bound to the given context
+func (c *DefaultClient) GetPipelineHistoryPageContext(ctx context.Context, name string, offset int) (*PipelineHistoryPage, error) {
 	res := new(PipelineHistoryPage)
-	err := c.getJSON(fmt.Sprintf("/go/api/pipelines/%s/history/%d", name, offset), nil, res)
+	headers := c.negotiate(ctx, apiPipelineHistory).headers()
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/pipelines/%s/history/%d", url.PathEscape(name), offset), headers, nil, res)
 	return res, err
 }
 
 // GetPipelineStatus allows users to check if the pipeline is paused, locked and
 // schedulable.
 func (c *DefaultClient) GetPipelineStatus(name string) (*PipelineStatus, error) {
+	return c.GetPipelineStatusContext(context.Background(), name)
+}
+
+// GetPipelineStatusContext is the same as GetPipelineStatus, bound to the given context
+func (c *DefaultClient) GetPipelineStatusContext(ctx context.Context, name string) (*PipelineStatus, error) {
 	res := new(PipelineStatus)
-	err := c.getJSON(fmt.Sprintf("/go/api/pipelines/%s/status", name), nil, res)
+	headers := c.negotiate(ctx, apiPipelineStatus).headers()
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/pipelines/%s/status", url.PathEscape(name)), headers, nil, res)
 	return res, err
 }
 
 // PausePipeline pauses the specified pipeline using the given cause
 func (c *DefaultClient) PausePipeline(name, cause string) (*SimpleMessage, error) {
-	data := struct{ PauseCause string }{cause}
+	return c.PausePipelineContext(context.Background(), name, cause)
+}
+
+// PausePipelineContext is the same as PausePipeline, bound to the given context
+func (c *DefaultClient) PausePipelineContext(ctx context.Context, name, cause string) (*SimpleMessage, error) {
+	data := struct {
+		PauseCause string `json:"pause_cause"`
+	}{cause}
 	res := new(SimpleMessage)
-	// The headers bellow only work with gocd 18.2
-	// headers := map[string]string{"Accept": "application/vnd.go.cd.v1+json", "X-GoCD-Confirm": "true"}
-	headers := map[string]string{"Accept": "application/json", "Confirm": "true"}
-	err := c.postJSON(fmt.Sprintf("/go/api/pipelines/%s/pause", name), headers, data, res)
+	headers := c.negotiate(ctx, apiPipelinePause).headers()
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/pipelines/%s/pause", url.PathEscape(name)), headers, data, res)
 	return res, err
 }
 
 // UnpausePipeline unpauses the specified pipeline
 func (c *DefaultClient) UnpausePipeline(name string) (*SimpleMessage, error) {
+	return c.UnpausePipelineContext(context.Background(), name)
+}
+
+// UnpausePipelineContext is the same as UnpausePipeline, bound to the given context
+func (c *DefaultClient) UnpausePipelineContext(ctx context.Context, name string) (*SimpleMessage, error) {
 	res := new(SimpleMessage)
-	// The headers bellow only work with gocd 18.2 and over
-	//headers := map[string]string{"Accept": "application/vnd.go.cd.v1+json", "X-GoCD-Confirm": "true"}
-	headers := map[string]string{"Accept": "application/json", "Confirm": "true"}
-	err := c.postJSON(fmt.Sprintf("/go/api/pipelines/%s/unpause", name), headers, nil, res)
+	headers := c.negotiate(ctx, apiPipelineUnpause).headers()
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/pipelines/%s/unpause", url.PathEscape(name)), headers, nil, res)
 	return res, err
 }
 
@@ -95,8 +125,122 @@ func (c *DefaultClient) UnpausePipeline(name string) (*SimpleMessage, error) {
 // running instance of the pipeline.
 // Requires GoCD version 18.2.0+
 func (c *DefaultClient) UnlockPipeline(name string) (*SimpleMessage, error) {
+	return c.UnlockPipelineContext(context.Background(), name)
+}
+
+// UnlockPipelineContext is the same as UnlockPipeline, bound to the given context
+func (c *DefaultClient) UnlockPipelineContext(ctx context.Context, name string) (*SimpleMessage, error) {
 	res := new(SimpleMessage)
-	headers := map[string]string{"Accept": "application/vnd.go.cd.v1+json", "X-GoCD-Confirm": "true"}
-	err := c.postJSON(fmt.Sprintf("/go/api/pipelines/%s/unlock", name), headers, nil, res)
+	headers := c.negotiate(ctx, apiPipelineUnlock).headers()
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/pipelines/%s/unlock", url.PathEscape(name)), headers, nil, res)
 	return res, err
 }
+
+// ScheduleOptions customizes the run of a pipeline triggered by SchedulePipeline
+type ScheduleOptions struct {
+	// Materials pins the revisions of some of the pipeline's materials, the
+	// others are scheduled with their latest revision
+	Materials []ScheduleMaterial `json:"materials,omitempty"`
+	// EnvironmentVariables overrides the values of some of the environment
+	// variables of the pipeline. Mark the secure ones as such so that GoCD
+	// doesn't display their value.
+	EnvironmentVariables []EnvironmentVariable `json:"environment_variables,omitempty"`
+	// UpdateMaterialsBeforeScheduling makes GoCD check the materials for new
+	// revisions before triggering the run, which it does when nil
+	UpdateMaterialsBeforeScheduling *bool `json:"update_materials_before_scheduling,omitempty"`
+	// TrackRun makes SchedulePipeline record the counter of the last run of
+	// the pipeline before scheduling it, for GetScheduledPipelineInstance to
+	// find the triggered run. It costs an extra request.
+	TrackRun bool `json:"-"`
+}
+
+// ScheduleMaterial is the revision of a material, identified by its
+// fingerprint (see Material.Fingerprint), to schedule a pipeline with
+type ScheduleMaterial struct {
+	Fingerprint string `json:"fingerprint"`
+	Revision    string `json:"revision"`
+}
+
+// ScheduleResult is what GoCD answers when it accepts to schedule a pipeline
+type ScheduleResult struct {
+	Message string
+	// Pipeline is the name of the scheduled pipeline
+	Pipeline string
+	// PreviousCounter is the counter of the last run of the pipeline when it
+	// was scheduled (0 when it never ran): GoCD schedules the pipeline
+	// asynchronously, the triggered run gets a greater counter once created.
+	// See GetScheduledPipelineInstance.
+	//
+	// It's only recorded with ScheduleOptions.TrackRun, which Tracked tells.
+	// It's best-effort: it's read right before scheduling the pipeline, a run
+	// triggered by anyone else in between is taken for the triggered one.
+	PreviousCounter int
+	Tracked         bool
+}
+
+// ErrNotScheduledYet is returned by GetScheduledPipelineInstance while GoCD
+// didn't create the triggered run yet
+var ErrNotScheduledYet = errors.New("gocd: the pipeline run isn't scheduled yet")
+
+// ErrRunNotTracked is returned by GetScheduledPipelineInstance for a pipeline
+// scheduled without ScheduleOptions.TrackRun
+var ErrRunNotTracked = errors.New("gocd: the pipeline run wasn't tracked when scheduled")
+
+// SchedulePipeline triggers a run of the specified pipeline, with the given
+// options. Requires GoCD version 18.2.0+
+func (c *DefaultClient) SchedulePipeline(name string, opts ScheduleOptions) (*ScheduleResult, error) {
+	return c.SchedulePipelineContext(context.Background(), name, opts)
+}
+
+// SchedulePipelineContext is the same as SchedulePipeline, bound to the given context
+func (c *DefaultClient) SchedulePipelineContext(ctx context.Context, name string, opts ScheduleOptions) (*ScheduleResult, error) {
+	res := &ScheduleResult{Pipeline: name, Tracked: opts.TrackRun}
+	if opts.TrackRun {
+		history, err := c.GetPipelineHistoryPageContext(ctx, name, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range history.Pipelines {
+			if p.Counter > res.PreviousCounter {
+				res.PreviousCounter = p.Counter
+			}
+		}
+	}
+
+	msg := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiPipelineSchedule).headers()
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/pipelines/%s/schedule", url.PathEscape(name)), headers, opts, msg); err != nil {
+		return nil, err
+	}
+	res.Message = msg.Message
+	return res, nil
+}
+
+// GetScheduledPipelineInstance returns the run triggered by SchedulePipeline
+// with ScheduleOptions.TrackRun, or ErrNotScheduledYet while GoCD is still
+// preparing it. Should the pipeline have been triggered again meanwhile, the
+// earliest of the new runs is returned.
+func (c *DefaultClient) GetScheduledPipelineInstance(result *ScheduleResult) (*PipelineInstance, error) {
+	return c.GetScheduledPipelineInstanceContext(context.Background(), result)
+}
+
+// GetScheduledPipelineInstanceContext is the same as GetScheduledPipelineInstance, bound to the given context
+func (c *DefaultClient) GetScheduledPipelineInstanceContext(ctx context.Context, result *ScheduleResult) (*PipelineInstance, error) {
+	if !result.Tracked {
+		return nil, ErrRunNotTracked
+	}
+	history, err := c.GetPipelineHistoryPageContext(ctx, result.Pipeline, 0)
+	if err != nil {
+		return nil, err
+	}
+	var scheduled *PipelineInstance
+	for i, p := range history.Pipelines {
+		if p.Counter > result.PreviousCounter && (scheduled == nil || p.Counter < scheduled.Counter) {
+			scheduled = &history.Pipelines[i]
+		}
+	}
+	if scheduled == nil {
+		return nil, ErrNotScheduledYet
+	}
+	return scheduled, nil
+}
@@ -1,6 +1,10 @@
 package gocd
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,3 +80,119 @@ func TestGetPipelineStatus(t *testing.T) {
 	assert.Equal(t, false, s.Schedulable)
 	assert.Equal(t, false, s.Locked)
 }
+
+func TestGetPipelineStatusContextCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/status", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	})
+	defer server.Close()
+
+	_, err := client.GetPipelineStatusContext(ctx, "pipeline1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+}
+
+func TestPausePipeline(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/pause", serveFileAsJSON(t, "POST", "test-fixtures/pause_pipeline.json", 1, jsonBodyValidator(`{"pause_cause":"maintenance"}`)))
+	defer server.Close()
+	msg, err := client.PausePipeline("pipeline1", "maintenance")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pipeline 'pipeline1' paused successfully.", msg.Message)
+}
+
+func TestSchedulePipeline(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{
+		"materials": [{"fingerprint": "f6e7a3899c55e1682ffb00383bdf8f882bcee2141e79a8728254190a1fddcf4f", "revision": "c194b49db102b705ebc13e604e490ae13ac92d96"}],
+		"environment_variables": [
+			{"name": "USERNAME", "secure": false, "value": "bob"},
+			{"name": "SSH_PASSPHRASE", "secure": true, "value": "p@ssw0rd"}
+		],
+		"update_materials_before_scheduling": false
+	}`
+	scheduled := false
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	mux.HandleFunc("/go/api/pipelines/pipeline1/schedule", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("X-GoCD-Confirm"))
+		serveFileAsJSON(t, "POST", "test-fixtures/schedule_pipeline.json", 1, jsonBodyValidator(expectedBody))(w, r)
+		scheduled = true
+	})
+	mux.HandleFunc("/go/api/pipelines/pipeline1/history/0", func(w http.ResponseWriter, r *http.Request) {
+		page := PipelineHistoryPage{Pipelines: []PipelineInstance{{Name: "pipeline1", Counter: 11}, {Name: "pipeline1", Counter: 10}}}
+		if scheduled {
+			page.Pipelines = append([]PipelineInstance{{Name: "pipeline1", Counter: 12}}, page.Pipelines...)
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := New(server.URL, testUsername, testPassword)
+
+	result, err := client.SchedulePipeline("pipeline1", ScheduleOptions{
+		Materials: []ScheduleMaterial{
+			{Fingerprint: "f6e7a3899c55e1682ffb00383bdf8f882bcee2141e79a8728254190a1fddcf4f", Revision: "c194b49db102b705ebc13e604e490ae13ac92d96"},
+		},
+		EnvironmentVariables: []EnvironmentVariable{
+			{Name: "USERNAME", Value: "bob"},
+			{Name: "SSH_PASSPHRASE", Value: "p@ssw0rd", Secure: true},
+		},
+		UpdateMaterialsBeforeScheduling: Bool(false),
+		TrackRun:                        true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Request to schedule pipeline pipeline1 accepted", result.Message)
+	assert.Equal(t, "pipeline1", result.Pipeline)
+	assert.Equal(t, 11, result.PreviousCounter)
+	assert.True(t, result.Tracked)
+
+	instance, err := client.GetScheduledPipelineInstance(result)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, instance.Counter)
+}
+
+func TestGetScheduledPipelineInstanceNotYet(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/history/0", serveFileAsJSON(t, "GET", "test-fixtures/get_pipeline_history_page.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+
+	instance, err := client.GetScheduledPipelineInstance(&ScheduleResult{Pipeline: "pipeline1", PreviousCounter: 11, Tracked: true})
+	assert.Equal(t, ErrNotScheduledYet, err)
+	assert.Nil(t, instance)
+}
+
+func TestSchedulePipelineWithoutTracking(t *testing.T) {
+	t.Parallel()
+	// the history isn't fetched
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/schedule", serveFileAsJSON(t, "POST", "test-fixtures/schedule_pipeline.json", 1, jsonBodyValidator(`{}`)))
+	defer server.Close()
+
+	result, err := client.SchedulePipeline("pipeline1", ScheduleOptions{})
+	assert.NoError(t, err)
+	assert.False(t, result.Tracked)
+	instance, err := client.GetScheduledPipelineInstance(result)
+	assert.Equal(t, ErrRunNotTracked, err)
+	assert.Nil(t, instance)
+}
+
+func TestSchedulePipelineConflict(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	mux.HandleFunc("/go/api/pipelines/pipeline1/history/0", serveFileAsJSON(t, "GET", "test-fixtures/get_pipeline_history_page.json", 0, DummyRequestBodyValidator))
+	mux.HandleFunc("/go/api/pipelines/pipeline1/schedule", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message": "Failed to trigger pipeline [pipeline1] { Stage [stage1] in pipeline [pipeline1] is still in progress }"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := New(server.URL, testUsername, testPassword)
+
+	result, err := client.SchedulePipeline("pipeline1", ScheduleOptions{})
+	assert.True(t, hasStatus(err, http.StatusConflict))
+	assert.Nil(t, result)
+}
@@ -0,0 +1,122 @@
+package gocd
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how the requests failing with a transient error (a
+// network error or one of RetryableStatusCodes) are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// No request is retried below 2.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles with every
+	// further attempt, up to MaxBackoff, and is randomized (jitter) so that
+	// several clients don't all retry at the same time. MaxBackoff also caps
+	// the delay a server asks for with a Retry-After header.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RetryableStatusCodes are the statuses worth retrying, typically sent by a
+	// load balancer while GoCD restarts.
+	RetryableStatusCodes []int
+	// Methods lists the HTTP methods the policy applies to. Only the idempotent
+	// GET and HEAD requests are retried when empty, add POST, PATCH, PUT or
+	// DELETE to opt-in for them.
+	Methods []string
+}
+
+// DefaultRetryPolicy is a sensible RetryPolicy to give to WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+	RetryableStatusCodes: []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// WithRetryPolicy retries the failed requests according to the given policy.
+// Requests are not retried unless this option is given.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *DefaultClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// attempts returns how many times a request with the given method may be sent
+func (p RetryPolicy) attempts(method string) int {
+	methods := p.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+	for _, m := range methods {
+		if m == method && p.MaxAttempts > 1 {
+			return p.MaxAttempts
+		}
+	}
+	return 1
+}
+
+// retryable tells whether the outcome of an attempt is a transient failure
+func (p RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if resp == nil {
+		// the request didn't get through, e.g. connection refused or reset
+		return err != nil
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns how long to wait after the given (failed) attempt. The
+// server's Retry-After header, when any, takes precedence, up to MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+			return d
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// "equal jitter": wait at least half of the backoff
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds
+// or an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
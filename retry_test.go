@@ -0,0 +1,113 @@
+package gocd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:          3,
+	MinBackoff:           time.Millisecond,
+	MaxBackoff:           5 * time.Millisecond,
+	RetryableStatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable},
+}
+
+// newFlakyServer answers with the given failing status to the first failures requests
+func newFlakyServer(failures int32, status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"paused": true, "message": "ok"}`))
+	}))
+}
+
+func TestRetryGet(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := newFlakyServer(2, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRetryPolicy(testRetryPolicy))
+	status, err := client.GetPipelineStatus("pipeline1")
+	assert.NoError(t, err)
+	assert.True(t, status.Paused)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestRetryGiveUp(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := newFlakyServer(5, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRetryPolicy(testRetryPolicy))
+	_, err := client.GetPipelineStatus("pipeline1")
+	assert.True(t, hasStatus(err, http.StatusBadGateway))
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestRetryNonRetryableStatus(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := newFlakyServer(1, http.StatusNotFound, &calls)
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRetryPolicy(testRetryPolicy))
+	_, err := client.GetPipelineStatus("pipeline1")
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestRetryPostIsOptIn(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := newFlakyServer(2, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRetryPolicy(testRetryPolicy), WithServerVersion("18.10.0"))
+	_, err := client.UnpausePipeline("pipeline1")
+	assert.True(t, hasStatus(err, http.StatusServiceUnavailable))
+	assert.Equal(t, int32(1), calls)
+
+	policy := testRetryPolicy
+	policy.Methods = []string{http.MethodGet, http.MethodPost}
+	client = NewWithOptions(server.URL, WithRetryPolicy(policy), WithServerVersion("18.10.0"))
+	_, err = client.UnpausePipeline("pipeline1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 10: time.Second} {
+		d := policy.backoff(attempt, nil)
+		assert.True(t, d >= max/2 && d <= max, "attempt %d waits %s", attempt, d)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+	assert.Equal(t, time.Second, policy.backoff(1, resp))
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Duration(0), policy.backoff(1, resp))
+	resp.Header.Set("Retry-After", "soon")
+	assert.True(t, policy.backoff(1, resp) <= 100*time.Millisecond)
+}
+
+func TestRetryAfterCapped(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	assert.Equal(t, time.Second, policy.backoff(1, resp))
+	resp.Header.Set("Retry-After", time.Now().Add(24*time.Hour).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Second, policy.backoff(1, resp))
+
+	// without MaxBackoff the server is trusted
+	policy.MaxBackoff = 0
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, time.Hour, policy.backoff(1, resp))
+}
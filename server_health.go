@@ -1,5 +1,10 @@
 package gocd
 
+import (
+	"context"
+	"net/http"
+)
+
 type ServerHealthMessage struct {
 	Message string `json:"message"`
 	Detail  string `json:"detail"`
@@ -16,8 +21,12 @@ func (s *ServerHealthMessage) IsError() bool {
 }
 
 func (c *DefaultClient) GetServerHealthMessages() ([]*ServerHealthMessage, error) {
+	return c.GetServerHealthMessagesContext(context.Background())
+}
+
+func (c *DefaultClient) GetServerHealthMessagesContext(ctx context.Context) ([]*ServerHealthMessage, error) {
 	res := []*ServerHealthMessage{}
-	headers := map[string]string{"Accept": "application/vnd.go.cd.v1+json"}
-	err := c.getJSON("/go/api/server_health_messages", headers, &res)
+	headers := c.negotiate(ctx, apiServerHealthMessages).headers()
+	err := c.do(ctx, http.MethodGet, "/api/server_health_messages", headers, nil, &res)
 	return res, err
 }
@@ -1,5 +1,12 @@
 package gocd
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
 // StageRun represent a stage run history event
 type StageRun struct {
 	ID                int    `json:"id"`
@@ -14,3 +21,111 @@ type StageRun struct {
 	RerunOfCounter    bool   `json:"rerun_of_counter"`
 	Scheduled         bool   `json:"scheduled"`
 }
+
+// StageInstance represents a run of a stage along with the pipeline run it
+// belongs to
+type StageInstance struct {
+	StageRun
+	PipelineName          string `json:"pipeline_name"`
+	PipelineCounter       int    `json:"pipeline_counter"`
+	FetchMaterials        bool   `json:"fetch_materials"`
+	CleanWorkingDirectory bool   `json:"clean_working_directory"`
+	ArtifactsDeleted      bool   `json:"artifacts_deleted"`
+}
+
+// StageHistoryPage represents a page of the history of runs of a stage
+type StageHistoryPage struct {
+	Stages     []StageInstance `json:"stages"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// RunStage triggers the given stage of the given pipeline run, e.g. a stage
+// waiting for a manual approval
+func (c *DefaultClient) RunStage(pipeline string, pipelineCounter int, stage string) (*SimpleMessage, error) {
+	return c.RunStageContext(context.Background(), pipeline, pipelineCounter, stage)
+}
+
+// RunStageContext is the same as RunStage, bound to the given context
+func (c *DefaultClient) RunStageContext(ctx context.Context, pipeline string, pipelineCounter int, stage string) (*SimpleMessage, error) {
+	res := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiStageOperations).headers()
+	resource := fmt.Sprintf("/api/stages/%s/%d/%s/run", url.PathEscape(pipeline), pipelineCounter, url.PathEscape(stage))
+	err := c.do(ctx, http.MethodPost, resource, headers, nil, res)
+	return res, err
+}
+
+// CancelStage cancels the given run of a stage
+func (c *DefaultClient) CancelStage(pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error) {
+	return c.CancelStageContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter)
+}
+
+// CancelStageContext is the same as CancelStage, bound to the given context
+func (c *DefaultClient) CancelStageContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error) {
+	res := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiStageOperations).headers()
+	err := c.do(ctx, http.MethodPost, stageResource(pipeline, pipelineCounter, stage, stageCounter)+"/cancel", headers, nil, res)
+	return res, err
+}
+
+// RerunFailedJobs reruns the jobs which failed in the given run of a stage
+func (c *DefaultClient) RerunFailedJobs(pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error) {
+	return c.RerunFailedJobsContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter)
+}
+
+// RerunFailedJobsContext is the same as RerunFailedJobs, bound to the given context
+func (c *DefaultClient) RerunFailedJobsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*SimpleMessage, error) {
+	res := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiStageOperations).headers()
+	err := c.do(ctx, http.MethodPost, stageResource(pipeline, pipelineCounter, stage, stageCounter)+"/run-failed-jobs", headers, nil, res)
+	return res, err
+}
+
+// RerunSelectedJobs reruns the given jobs of the given run of a stage
+func (c *DefaultClient) RerunSelectedJobs(pipeline string, pipelineCounter int, stage string, stageCounter int, jobs []string) (*SimpleMessage, error) {
+	return c.RerunSelectedJobsContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter, jobs)
+}
+
+// RerunSelectedJobsContext is the same as RerunSelectedJobs, bound to the given context
+func (c *DefaultClient) RerunSelectedJobsContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int, jobs []string) (*SimpleMessage, error) {
+	data := struct {
+		Jobs []string `json:"jobs"`
+	}{jobs}
+	res := new(SimpleMessage)
+	headers := c.negotiate(ctx, apiStageOperations).headers()
+	err := c.do(ctx, http.MethodPost, stageResource(pipeline, pipelineCounter, stage, stageCounter)+"/run-selected-jobs", headers, data, res)
+	return res, err
+}
+
+// GetStageInstance returns the given run of a stage
+func (c *DefaultClient) GetStageInstance(pipeline string, pipelineCounter int, stage string, stageCounter int) (*StageInstance, error) {
+	return c.GetStageInstanceContext(context.Background(), pipeline, pipelineCounter, stage, stageCounter)
+}
+
+// GetStageInstanceContext is the same as GetStageInstance, bound to the given context
+func (c *DefaultClient) GetStageInstanceContext(ctx context.Context, pipeline string, pipelineCounter int, stage string, stageCounter int) (*StageInstance, error) {
+	res := new(StageInstance)
+	headers := c.negotiate(ctx, apiStageInstance).headers()
+	resource := fmt.Sprintf("/api/stages/%s/%s/instance/%d/%d", url.PathEscape(pipeline), url.PathEscape(stage), pipelineCounter, stageCounter)
+	err := c.do(ctx, http.MethodGet, resource, headers, nil, res)
+	return res, err
+}
+
+// GetStageHistory lists the runs of a stage, the most recent first. Supports
+// pagination using offset which tells the API how many runs to skip.
+func (c *DefaultClient) GetStageHistory(pipeline, stage string, offset int) (*StageHistoryPage, error) {
+	return c.GetStageHistoryContext(context.Background(), pipeline, stage, offset)
+}
+
+// GetStageHistoryContext is the same as GetStageHistory, bound to the given context
+func (c *DefaultClient) GetStageHistoryContext(ctx context.Context, pipeline, stage string, offset int) (*StageHistoryPage, error) {
+	res := new(StageHistoryPage)
+	headers := c.negotiate(ctx, apiStageHistory).headers()
+	resource := fmt.Sprintf("/api/stages/%s/%s/history/%d", url.PathEscape(pipeline), url.PathEscape(stage), offset)
+	err := c.do(ctx, http.MethodGet, resource, headers, nil, res)
+	return res, err
+}
+
+// stageResource returns the resource of the given run of a stage
+func stageResource(pipeline string, pipelineCounter int, stage string, stageCounter int) string {
+	return fmt.Sprintf("/api/stages/%s/%d/%s/%d", url.PathEscape(pipeline), pipelineCounter, url.PathEscape(stage), stageCounter)
+}
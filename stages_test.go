@@ -0,0 +1,83 @@
+package gocd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveStageOperation checks the confirmation header of the stage operations
+func serveStageOperation(t *testing.T, filepath string, requestBodyValidator func(string) error) func(http.ResponseWriter, *http.Request) {
+	serve := serveFileAsJSON(t, "POST", filepath, 1, requestBodyValidator)
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("X-GoCD-Confirm"))
+		serve(w, r)
+	}
+}
+
+func TestRunStage(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/stages/pipeline1/3/stage2/run", serveStageOperation(t, "test-fixtures/run_stage.json", DummyRequestBodyValidator))
+	defer server.Close()
+	msg, err := client.RunStage("pipeline1", 3, "stage2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Request to schedule stage pipeline1/3/stage2 accepted", msg.Message)
+}
+
+func TestCancelStage(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/stages/pipeline1/3/stage1/1/cancel", serveStageOperation(t, "test-fixtures/cancel_stage.json", DummyRequestBodyValidator))
+	defer server.Close()
+	msg, err := client.CancelStage("pipeline1", 3, "stage1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Stage cancelled successfully.", msg.Message)
+}
+
+func TestRerunFailedJobs(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/stages/pipeline1/3/stage1/1/run-failed-jobs", serveStageOperation(t, "test-fixtures/rerun_jobs.json", DummyRequestBodyValidator))
+	defer server.Close()
+	msg, err := client.RerunFailedJobs("pipeline1", 3, "stage1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Request to rerun jobs accepted", msg.Message)
+}
+
+func TestRerunSelectedJobs(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/stages/pipeline1/3/stage1/1/run-selected-jobs", serveStageOperation(t, "test-fixtures/rerun_jobs.json", jsonBodyValidator(`{"jobs":["job1","job2"]}`)))
+	defer server.Close()
+	msg, err := client.RerunSelectedJobs("pipeline1", 3, "stage1", 1, []string{"job1", "job2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Request to rerun jobs accepted", msg.Message)
+}
+
+func TestGetStageInstance(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/stages/pipeline1/stage1/instance/3/1", serveFileAsJSON(t, "GET", "test-fixtures/get_stage_instance.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+	stage, err := client.GetStageInstance("pipeline1", 3, "stage1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "stage1", stage.Name)
+	assert.Equal(t, "1", stage.Counter)
+	assert.Equal(t, "Failed", stage.Result)
+	assert.Equal(t, "pipeline1", stage.PipelineName)
+	assert.Equal(t, 3, stage.PipelineCounter)
+	assert.True(t, stage.FetchMaterials)
+	assert.Equal(t, 2, len(stage.Jobs))
+	assert.Equal(t, "job2", stage.Jobs[1].Name)
+	assert.Equal(t, "Failed", stage.Jobs[1].Result)
+}
+
+func TestGetStageHistory(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/stages/pipeline1/stage1/history/0", serveFileAsJSON(t, "GET", "test-fixtures/get_stage_history.json", 0, DummyRequestBodyValidator))
+	defer server.Close()
+	history, err := client.GetStageHistory("pipeline1", "stage1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(history.Stages))
+	assert.Equal(t, "2", history.Stages[0].Counter)
+	assert.Equal(t, "Passed", history.Stages[0].Result)
+	assert.Equal(t, "1", history.Stages[1].Counter)
+	assert.Equal(t, Pagination{Offset: 0, Total: 2, PageSize: 10}, history.Pagination)
+}
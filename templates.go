@@ -0,0 +1,150 @@
+package gocd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TemplateConfig is a pipeline template, the stages of the pipelines created
+// from it
+type TemplateConfig struct {
+	Name   string        `json:"name"`
+	Stages []StageConfig `json:"stages"`
+
+	// Pipelines lists the names of the pipelines using the template. It's
+	// only filled in by GetAllTemplates.
+	Pipelines []string `json:"-"`
+	// ETag identifies the version of the template GoCD sent, it's required
+	// to update it. See UpdateTemplate.
+	ETag string `json:"-"`
+}
+
+// UsesTemplate reports whether the pipeline is created from the given template,
+// as listed by GetAllTemplates
+func (p Pipeline) UsesTemplate(template *TemplateConfig) bool {
+	for _, name := range template.Pipelines {
+		if name == p.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// PipelinesUsingTemplate returns the pipelines of the group created from the
+// given template, as listed by GetAllTemplates
+func (pg *PipelineGroup) PipelinesUsingTemplate(template *TemplateConfig) []Pipeline {
+	pipelines := []Pipeline{}
+	for _, p := range pg.Pipelines {
+		if p.UsesTemplate(template) {
+			pipelines = append(pipelines, p)
+		}
+	}
+	return pipelines
+}
+
+// GetAllTemplates lists the templates, along with the pipelines using them.
+// Their stages aren't listed, see GetTemplate.
+func (c *DefaultClient) GetAllTemplates() ([]*TemplateConfig, error) {
+	return c.GetAllTemplatesContext(context.Background())
+}
+
+// GetAllTemplatesContext is the same as GetAllTemplates, bound to the given context
+func (c *DefaultClient) GetAllTemplatesContext(ctx context.Context) ([]*TemplateConfig, error) {
+	type pipeline struct {
+		Name string `json:"name"`
+	}
+	type template struct {
+		Name     string `json:"name"`
+		Embedded struct {
+			Pipelines []pipeline `json:"pipelines"`
+		} `json:"_embedded"`
+	}
+	type AllTemplatesResponse struct {
+		Embedded struct {
+			Templates []template `json:"templates"`
+		} `json:"_embedded"`
+	}
+	var responseFormat AllTemplatesResponse
+
+	headers := c.negotiate(ctx, apiTemplates).headers()
+	if err := c.do(ctx, http.MethodGet, "/api/admin/templates", headers, nil, &responseFormat); err != nil {
+		return []*TemplateConfig{}, err
+	}
+	templates := make([]*TemplateConfig, len(responseFormat.Embedded.Templates))
+	for i, t := range responseFormat.Embedded.Templates {
+		templates[i] = &TemplateConfig{Name: t.Name, Pipelines: []string{}}
+		for _, p := range t.Embedded.Pipelines {
+			templates[i].Pipelines = append(templates[i].Pipelines, p.Name)
+		}
+	}
+	return templates, nil
+}
+
+// GetTemplate returns the given template, along with its ETag
+func (c *DefaultClient) GetTemplate(name string) (*TemplateConfig, error) {
+	return c.GetTemplateContext(context.Background(), name)
+}
+
+// GetTemplateContext is the same as GetTemplate, bound to the given context
+func (c *DefaultClient) GetTemplateContext(ctx context.Context, name string) (*TemplateConfig, error) {
+	res := new(TemplateConfig)
+	headers := c.negotiate(ctx, apiTemplates).headers()
+	resp, err := c.doResponse(ctx, http.MethodGet, fmt.Sprintf("/api/admin/templates/%s", url.PathEscape(name)), headers, nil, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// CreateTemplate creates a template. Returns the created template
+func (c *DefaultClient) CreateTemplate(template *TemplateConfig) (*TemplateConfig, error) {
+	return c.CreateTemplateContext(context.Background(), template)
+}
+
+// CreateTemplateContext is the same as CreateTemplate, bound to the given context
+func (c *DefaultClient) CreateTemplateContext(ctx context.Context, template *TemplateConfig) (*TemplateConfig, error) {
+	res := new(TemplateConfig)
+	headers := c.negotiate(ctx, apiTemplates).headers()
+	resp, err := c.doResponse(ctx, http.MethodPost, "/api/admin/templates", headers, template, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// UpdateTemplate replaces a template with the given one, provided it's still
+// the version identified by template.ETag: see IsConflict otherwise. Returns
+// the updated template, along with its new ETag
+func (c *DefaultClient) UpdateTemplate(template *TemplateConfig) (*TemplateConfig, error) {
+	return c.UpdateTemplateContext(context.Background(), template)
+}
+
+// UpdateTemplateContext is the same as UpdateTemplate, bound to the given context
+func (c *DefaultClient) UpdateTemplateContext(ctx context.Context, template *TemplateConfig) (*TemplateConfig, error) {
+	res := new(TemplateConfig)
+	headers, err := ifMatch(c.negotiate(ctx, apiTemplates).headers(), template.ETag)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doResponse(ctx, http.MethodPut, fmt.Sprintf("/api/admin/templates/%s", url.PathEscape(template.Name)), headers, template, res)
+	if err != nil {
+		return nil, err
+	}
+	res.ETag = resp.Header.Get("ETag")
+	return res, nil
+}
+
+// DeleteTemplate deletes the given template, which no pipeline may use
+func (c *DefaultClient) DeleteTemplate(name string) error {
+	return c.DeleteTemplateContext(context.Background(), name)
+}
+
+// DeleteTemplateContext is the same as DeleteTemplate, bound to the given context
+func (c *DefaultClient) DeleteTemplateContext(ctx context.Context, name string) error {
+	headers := c.negotiate(ctx, apiTemplates).headers()
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/admin/templates/%s", url.PathEscape(name)), headers, nil, nil)
+}
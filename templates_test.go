@@ -0,0 +1,134 @@
+package gocd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTemplateETag = `"a2b6e8e6f2a8c1d3b4e5f6a7b8c9d0e1"`
+
+func TestGetAllTemplates(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/templates", serveFileAsJSON(t, "GET", "test-fixtures/get_all_templates.json", 4, DummyRequestBodyValidator))
+	defer server.Close()
+	templates, err := client.GetAllTemplates()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(templates))
+	assert.Equal(t, "template1", templates[0].Name)
+	assert.Equal(t, []string{"up42", "down43"}, templates[0].Pipelines)
+	assert.Equal(t, "unused", templates[1].Name)
+	assert.Equal(t, []string{}, templates[1].Pipelines)
+}
+
+func TestPipelinesUsingTemplate(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, serveTestVersion)
+	mux.HandleFunc("/go/api/admin/templates", serveFileAsJSON(t, "GET", "test-fixtures/get_all_templates.json", 4, DummyRequestBodyValidator))
+	mux.HandleFunc("/go/api/config/pipeline_groups", serveFileAsJSON(t, "GET", "test-fixtures/get_pipeline_groups.json", 0, DummyRequestBodyValidator))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := New(server.URL, testUsername, testPassword)
+
+	templates, err := client.GetAllTemplates()
+	assert.NoError(t, err)
+	groups, err := client.GetPipelineGroups()
+	assert.NoError(t, err)
+
+	pipelines := groups[0].PipelinesUsingTemplate(templates[0])
+	assert.Equal(t, 1, len(pipelines))
+	assert.Equal(t, "up42", pipelines[0].Name)
+	assert.True(t, pipelines[0].UsesTemplate(templates[0]))
+	assert.False(t, pipelines[0].UsesTemplate(templates[1]))
+	assert.Equal(t, []Pipeline{}, groups[0].PipelinesUsingTemplate(templates[1]))
+}
+
+func TestGetTemplate(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/templates/template1", serveWithETag(testTemplateETag, serveFileAsJSON(t, "GET", "test-fixtures/get_template.json", 4, DummyRequestBodyValidator)))
+	defer server.Close()
+	template, err := client.GetTemplate("template1")
+	assert.NoError(t, err)
+	assert.Equal(t, testTemplateETag, template.ETag)
+	assert.Equal(t, "template1", template.Name)
+	assert.Equal(t, 1, len(template.Stages))
+	assert.Equal(t, "up42_stage", template.Stages[0].Name)
+	assert.Equal(t, "ls", template.Stages[0].Jobs[0].Tasks[0].Attributes.Command)
+}
+
+func TestCreateTemplate(t *testing.T) {
+	t.Parallel()
+	requestBodyValidator := func(body string) error {
+		var template TemplateConfig
+		if err := json.Unmarshal([]byte(body), &template); err != nil {
+			return err
+		}
+		assert.Equal(t, "template1", template.Name)
+		assert.Equal(t, "up42_job", template.Stages[0].Jobs[0].Name)
+		return nil
+	}
+	client, server := newTestAPIClient("/go/api/admin/templates", serveWithETag(testTemplateETag, serveFileAsJSON(t, "POST", "test-fixtures/get_template.json", 4, requestBodyValidator)))
+	defer server.Close()
+	template, err := client.CreateTemplate(&TemplateConfig{
+		Name: "template1",
+		Stages: []StageConfig{{
+			Name: "up42_stage",
+			Jobs: []JobConfig{{
+				Name:  "up42_job",
+				Tasks: []TaskConfig{{Type: "exec", Attributes: TaskAttributes{Command: "ls"}}},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "template1", template.Name)
+	assert.Equal(t, testTemplateETag, template.ETag)
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/templates/template1", func(w http.ResponseWriter, r *http.Request) {
+		RequestMethodCheck(t, r, "PUT")
+		if r.Header.Get("If-Match") != testTemplateETag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"message": "Someone has modified the configuration for template 'template1'. Please update your copy of the config with the changes."}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("ETag", `"new"`)
+		w.Write(body)
+	})
+	defer server.Close()
+
+	template := &TemplateConfig{Name: "template1", Stages: []StageConfig{{Name: "renamed_stage"}}, ETag: testTemplateETag}
+	updated, err := client.UpdateTemplate(template)
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed_stage", updated.Stages[0].Name)
+	assert.Equal(t, `"new"`, updated.ETag)
+
+	_, err = client.UpdateTemplate(updated)
+	assert.True(t, IsConflict(err))
+}
+
+func TestUpdateTemplateWithoutETag(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/templates/template1", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s without an ETag", r.Method)
+	})
+	defer server.Close()
+
+	_, err := client.UpdateTemplate(&TemplateConfig{Name: "template1"})
+	assert.Equal(t, ErrMissingETag, err)
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/admin/templates/template1", serveFileAsJSON(t, "DELETE", "test-fixtures/delete_template.json", 4, DummyRequestBodyValidator))
+	defer server.Close()
+	err := client.DeleteTemplate("template1")
+	assert.NoError(t, err)
+}
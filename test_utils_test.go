@@ -1,24 +1,60 @@
 package gocd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 const testUsername = "admin"
 const testPassword = "badger"
 
+const testVersionRoute = "/go/api/version"
+
+// serveTestVersion answers the server version detection of the test clients
+func serveTestVersion(writer http.ResponseWriter, request *http.Request) {
+	contents, err := ioutil.ReadFile("test-fixtures/get_server_version.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	writer.Header().Add("Content-Type", "application/vnd.go.cd.v1+json; charset=utf-8")
+	writer.Write(contents)
+}
+
 func DummyRequestBodyValidator(body string) error {
 	return nil
 }
 
+// jsonBodyValidator checks that the request body is the given JSON document,
+// whatever the order of its attributes
+func jsonBodyValidator(expectedBody string) func(string) error {
+	return func(body string) error {
+		var expected, actual interface{}
+		if err := json.Unmarshal([]byte(expectedBody), &expected); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(body), &actual); err != nil {
+			return fmt.Errorf("Request body (%s) isn't JSON: %v", body, err)
+		}
+		if !assert.ObjectsAreEqual(expected, actual) {
+			return fmt.Errorf("Request body (%s) didn't match the expected body (%s)", body, expectedBody)
+		}
+		return nil
+	}
+}
+
 func newTestAPIClient(route string, handler func(http.ResponseWriter, *http.Request)) (Client, *httptest.Server) {
 	newTestServerHandler := http.NewServeMux()
 	newTestServerHandler.HandleFunc(route, handler)
+	if route != testVersionRoute {
+		newTestServerHandler.HandleFunc(testVersionRoute, serveTestVersion)
+	}
 	newAPIServer := httptest.NewServer(newTestServerHandler)
 
 	return New(newAPIServer.URL, testUsername, testPassword), newAPIServer
@@ -48,6 +84,14 @@ func serveFileAsJSON(t *testing.T, method string, filepath string, apiVersion in
 	}
 }
 
+// serveWithETag serves the given handler's answer with the given ETag
+func serveWithETag(etag string, handler func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		handler(w, r)
+	}
+}
+
 func serveFileAsXML(t *testing.T, method, filepath string) func(http.ResponseWriter, *http.Request) {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		BasicAuthCheck(t, request)
@@ -0,0 +1,110 @@
+package gocd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit throttles the requests sent by the client (and by every
+// goroutine sharing it) to requestsPerSecond on average, allowing bursts of up
+// to burst requests. Retries count as requests. A requestsPerSecond <= 0 means
+// no limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *DefaultClient) {
+		if requestsPerSecond <= 0 {
+			c.rateLimiter = nil
+			return
+		}
+		c.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxConcurrentRequests caps the number of requests the client has in
+// flight at any time. Further requests wait for a slot to be released. A max
+// <= 0 means no limit.
+//
+// A streamed answer (DownloadArtifact, DownloadArtifactFolder,
+// GetJobConsoleLog) holds its slot until it's closed: a caller keeping max of
+// them open blocks every other call of the client.
+func WithMaxConcurrentRequests(max int) Option {
+	return func(c *DefaultClient) {
+		if max <= 0 {
+			c.inFlight = nil
+			return
+		}
+		c.inFlight = make(chan struct{}, max)
+	}
+}
+
+// rateLimiter is a token bucket: it holds up to burst tokens, refilled at rate
+// tokens per second, and every request takes one.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// take the token right away, even if it has yet to be refilled: the
+	// waiting time makes up for it and keeps the callers in order
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// acquire waits for the rate limiter and for an in-flight slot, when the
+// client is configured with them. The returned function releases the slot.
+func (c *DefaultClient) acquire(ctx context.Context) (func(), error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.inFlight <- struct{}{}:
+		return func() { <-c.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
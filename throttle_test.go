@@ -0,0 +1,98 @@
+package gocd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+	var current, max int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithMaxConcurrentRequests(2))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetPipelineStatus("pipeline1")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), max)
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRateLimit(20, 2))
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		_, err := client.GetPipelineStatus("pipeline1")
+		assert.NoError(t, err)
+	}
+	// the 2 first requests use the burst, the 4 others wait 50ms each
+	assert.True(t, time.Since(start) >= 190*time.Millisecond, "6 requests took %s", time.Since(start))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	client = NewWithOptions(server.URL, WithRateLimit(0.1, 1))
+	_, err := client.GetPipelineStatusContext(ctx, "pipeline1")
+	assert.NoError(t, err)
+	_, err = client.GetPipelineStatusContext(ctx, "pipeline1")
+	assert.Error(t, err)
+	assert.Equal(t, int32(7), calls)
+}
+
+func TestThrottleWithoutLimit(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"paused": true}`))
+	}))
+	defer server.Close()
+
+	for _, opt := range []Option{
+		WithMaxConcurrentRequests(0),
+		WithMaxConcurrentRequests(-1),
+		WithRateLimit(0, 1),
+		WithRateLimit(-5, 1),
+	} {
+		client := NewWithOptions(server.URL, opt).(*DefaultClient)
+		assert.Nil(t, client.inFlight)
+		assert.Nil(t, client.rateLimiter)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		for i := 0; i < 3; i++ {
+			_, err := client.GetPipelineStatusContext(ctx, "pipeline1")
+			assert.NoError(t, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,244 @@
+package gocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Logger is what the client logs its requests and responses with, see
+// WithLogger. *slog.Logger implements it.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...interface{})
+}
+
+// RequestInfo describes a request about to be sent to GoCD. Credentials are
+// scrubbed from it.
+type RequestInfo struct {
+	Method string
+	URL    string
+	Header http.Header
+	// Body is only filled when WithBodyLogging is given
+	Body []byte
+}
+
+// ResponseInfo describes the outcome of a request sent to GoCD. Credentials
+// are scrubbed from it.
+type ResponseInfo struct {
+	Method string
+	URL    string
+	// StatusCode is 0 when the request failed before getting an answer
+	StatusCode int
+	Header     http.Header
+	// Body is only filled when WithBodyLogging is given
+	Body    []byte
+	Latency time.Duration
+	Err     error
+}
+
+// Hooks are called around every request sent to GoCD, retries included
+type Hooks struct {
+	OnRequest  func(ctx context.Context, req *RequestInfo)
+	OnResponse func(ctx context.Context, res *ResponseInfo)
+}
+
+// WithLogger logs every request (method, URL, status and latency) at debug
+// level with the given logger, and the failed ones at warning level
+func WithLogger(logger Logger) Option {
+	return func(c *DefaultClient) {
+		c.logger = logger
+	}
+}
+
+// WithHooks calls the given hooks around every request sent to GoCD
+func WithHooks(hooks Hooks) Option {
+	return func(c *DefaultClient) {
+		c.hooks = hooks
+	}
+}
+
+// WithBodyLogging adds the (scrubbed) request and response bodies to the logs
+// and to what the hooks receive
+func WithBodyLogging(enabled bool) Option {
+	return func(c *DefaultClient) {
+		c.logBodies = enabled
+	}
+}
+
+// traceRequest logs req and calls the OnRequest hook, if any
+func (c *DefaultClient) traceRequest(ctx context.Context, req *http.Request) {
+	if c.logger == nil && c.hooks.OnRequest == nil {
+		return
+	}
+	info := &RequestInfo{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+		Header: scrubHeader(req.Header),
+	}
+	if c.logBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			raw, _ := ioutil.ReadAll(body)
+			body.Close()
+			info.Body = scrubBody(raw, req.Header.Get("Content-Type"))
+		}
+	}
+
+	if c.logger != nil {
+		args := []interface{}{"method", info.Method, "url", info.URL}
+		if len(info.Body) > 0 {
+			args = append(args, "body", string(info.Body))
+		}
+		c.logger.Log(ctx, slog.LevelDebug, "gocd request", args...)
+	}
+	if c.hooks.OnRequest != nil {
+		c.hooks.OnRequest(ctx, info)
+	}
+}
+
+// traceResponse logs the outcome of req and calls the OnResponse hook, if any
+func (c *DefaultClient) traceResponse(ctx context.Context, req *http.Request, resp *http.Response, body []byte, latency time.Duration, err error) {
+	if c.logger == nil && c.hooks.OnResponse == nil {
+		return
+	}
+	info := &ResponseInfo{
+		Method:  req.Method,
+		URL:     redactURL(req.URL),
+		Latency: latency,
+		Err:     err,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.Header = scrubHeader(resp.Header)
+	}
+	if c.logBodies && len(body) > 0 {
+		info.Body = scrubBody(body, resp.Header.Get("Content-Type"))
+	}
+
+	if c.logger != nil {
+		level := slog.LevelDebug
+		args := []interface{}{"method", info.Method, "url", info.URL, "status", info.StatusCode, "latency", info.Latency}
+		if err != nil {
+			level = slog.LevelWarn
+			args = append(args, "error", err)
+		} else if info.StatusCode >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+		if len(info.Body) > 0 {
+			args = append(args, "body", string(info.Body))
+		}
+		c.logger.Log(ctx, level, "gocd response", args...)
+	}
+	if c.hooks.OnResponse != nil {
+		c.hooks.OnResponse(ctx, info)
+	}
+}
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := scrubbed[name]; ok {
+			scrubbed.Set(name, redacted)
+		}
+	}
+	return scrubbed
+}
+
+// sensitiveFields are the JSON attributes, form fields and query parameters
+// whose values are never logged
+var sensitiveFields = map[string]bool{
+	"password":           true,
+	"encrypted_password": true,
+	"encrypted_value":    true,
+	"token":              true,
+	"access_token":       true,
+}
+
+// sensitiveText matches the sensitive fields in a body which is neither JSON
+// nor a form, e.g. an HTML error page: password=..., "token": "..."
+var sensitiveText = regexp.MustCompile(`(?i)((?:encrypted_password|encrypted_value|access_token|password|token)["']?\s*[:=]\s*["']?)[^"'&\s<]+`)
+
+// scrubBody redacts the credentials and the values of the secure environment
+// variables out of a body of the given content type
+func scrubBody(body []byte, contentType string) []byte {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return scrubForm(body)
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return sensitiveText.ReplaceAll(body, []byte("${1}"+redacted))
+	}
+	scrubbed, err := json.Marshal(scrubValue(doc))
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
+
+// scrubForm redacts the sensitive fields of a form
+func scrubForm(body []byte) []byte {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return sensitiveText.ReplaceAll(body, []byte("${1}"+redacted))
+	}
+	scrubValues(form)
+	return []byte(form.Encode())
+}
+
+func scrubValues(values url.Values) {
+	for k := range values {
+		if sensitiveFields[strings.ToLower(k)] {
+			values[k] = []string{redacted}
+		}
+	}
+}
+
+// redactURL returns the given URL without its user info and the values of its
+// sensitive query parameters
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	if redactedURL.User != nil {
+		redactedURL.User = url.User(redacted)
+	}
+	if redactedURL.RawQuery != "" {
+		query := redactedURL.Query()
+		scrubValues(query)
+		redactedURL.RawQuery = query.Encode()
+	}
+	return redactedURL.String()
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// {"name": "...", "secure": true, "value": "..."} is a secure variable
+		secure, _ := v["secure"].(bool)
+		for k, field := range v {
+			if sensitiveFields[strings.ToLower(k)] || (secure && k == "value") {
+				v[k] = redacted
+			} else {
+				v[k] = scrubValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubValue(item)
+		}
+		return v
+	}
+	return v
+}
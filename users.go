@@ -0,0 +1,146 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// User Object
+type User struct {
+	LoginName      string   `json:"login_name"`
+	DisplayName    string   `json:"display_name,omitempty"`
+	Enabled        bool     `json:"enabled"`
+	Email          string   `json:"email,omitempty"`
+	EmailMe        bool     `json:"email_me"`
+	CheckinAliases []string `json:"checkin_aliases,omitempty"`
+}
+
+// UserUpdate - The attributes of a user to change with UpdateUser. The nil
+// fields are left untouched, give an empty slice to clear the checkin aliases.
+// The notification filters of a user aren't part of the users API: GoCD
+// manages them through its notification filters API, on behalf of the
+// authenticated user only.
+type UserUpdate struct {
+	Enabled        *bool
+	Email          *string
+	EmailMe        *bool
+	CheckinAliases []string
+}
+
+// MarshalJSON only sends the attributes to change
+func (u UserUpdate) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	if u.Enabled != nil {
+		fields["enabled"] = *u.Enabled
+	}
+	if u.Email != nil {
+		fields["email"] = *u.Email
+	}
+	if u.EmailMe != nil {
+		fields["email_me"] = *u.EmailMe
+	}
+	if u.CheckinAliases != nil {
+		fields["checkin_aliases"] = u.CheckinAliases
+	}
+	return json.Marshal(fields)
+}
+
+// GetAllUsers - Lists all the users known to GoCD
+func (c *DefaultClient) GetAllUsers() ([]*User, error) {
+	return c.GetAllUsersContext(context.Background())
+}
+
+// GetAllUsersContext - Same as GetAllUsers, bound to the given context
+func (c *DefaultClient) GetAllUsersContext(ctx context.Context) ([]*User, error) {
+	type EmbeddedObj struct {
+		Users []*User `json:"users"`
+	}
+	type AllUsersResponse struct {
+		Embedded EmbeddedObj `json:"_embedded"`
+	}
+	var responseFormat AllUsersResponse
+
+	headers := c.negotiate(ctx, apiUsers).headers()
+	if err := c.do(ctx, http.MethodGet, "/api/users", headers, nil, &responseFormat); err != nil {
+		return []*User{}, err
+	}
+	return responseFormat.Embedded.Users, nil
+}
+
+// GetUser - Gets a user by its login name
+func (c *DefaultClient) GetUser(loginName string) (*User, error) {
+	return c.GetUserContext(context.Background(), loginName)
+}
+
+// GetUserContext - Same as GetUser, bound to the given context
+func (c *DefaultClient) GetUserContext(ctx context.Context, loginName string) (*User, error) {
+	var user *User
+
+	headers := c.negotiate(ctx, apiUsers).headers()
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/users/%s", url.PathEscape(loginName)), headers, nil, &user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CreateUser - Creates a user. Only its login name is required.
+// Enabled and EmailMe are only sent when true, leaving GoCD's defaults (an
+// enabled user who isn't emailed) otherwise: disable the user afterwards with
+// UpdateUser if need be.
+// Returns the created user
+func (c *DefaultClient) CreateUser(user *User) (*User, error) {
+	return c.CreateUserContext(context.Background(), user)
+}
+
+// CreateUserContext - Same as CreateUser, bound to the given context
+func (c *DefaultClient) CreateUserContext(ctx context.Context, user *User) (*User, error) {
+	var createdUser *User
+	data := struct {
+		*User
+		Enabled bool `json:"enabled,omitempty"`
+		EmailMe bool `json:"email_me,omitempty"`
+	}{user, user.Enabled, user.EmailMe}
+
+	headers := c.negotiate(ctx, apiUsers).headers()
+	if err := c.do(ctx, http.MethodPost, "/api/users", headers, data, &createdUser); err != nil {
+		return nil, err
+	}
+	return createdUser, nil
+}
+
+// UpdateUser - Updates some attributes of a user, e.g. to disable it.
+// Returns the updated user
+func (c *DefaultClient) UpdateUser(loginName string, update UserUpdate) (*User, error) {
+	return c.UpdateUserContext(context.Background(), loginName, update)
+}
+
+// UpdateUserContext - Same as UpdateUser, bound to the given context
+func (c *DefaultClient) UpdateUserContext(ctx context.Context, loginName string, update UserUpdate) (*User, error) {
+	var updatedUser *User
+
+	headers := c.negotiate(ctx, apiUsers).headers()
+	if err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/api/users/%s", url.PathEscape(loginName)), headers, update, &updatedUser); err != nil {
+		return nil, err
+	}
+	return updatedUser, nil
+}
+
+// DeleteUsers - Deletes the given users, which must be disabled first.
+// None of them is deleted if any can't be, the details are then in the
+// Data of the returned APIError.
+func (c *DefaultClient) DeleteUsers(loginNames []string) error {
+	return c.DeleteUsersContext(context.Background(), loginNames)
+}
+
+// DeleteUsersContext - Same as DeleteUsers, bound to the given context
+func (c *DefaultClient) DeleteUsersContext(ctx context.Context, loginNames []string) error {
+	data := struct {
+		Users []string `json:"users"`
+	}{loginNames}
+
+	headers := c.negotiate(ctx, apiUsers).headers()
+	return c.do(ctx, http.MethodDelete, "/api/users", headers, data, nil)
+}
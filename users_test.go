@@ -0,0 +1,110 @@
+package gocd
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllUsers(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/users", serveFileAsJSON(t, "GET", "test-fixtures/get_all_users.json", 2, DummyRequestBodyValidator))
+	defer server.Close()
+	users, err := client.GetAllUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(users))
+	user1 := users[0]
+	assert.Equal(t, "jdoe", user1.LoginName)
+	assert.Equal(t, "jdoe", user1.DisplayName)
+	assert.True(t, user1.Enabled)
+	assert.Equal(t, "jdoe@example.com", user1.Email)
+	assert.True(t, user1.EmailMe)
+	assert.Equal(t, []string{"jdoe", "johndoe"}, user1.CheckinAliases)
+	user2 := users[1]
+	assert.Equal(t, "bob", user2.LoginName)
+	assert.False(t, user2.Enabled)
+	assert.Equal(t, "", user2.Email)
+}
+
+func TestGetUser(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/users/jdoe", serveFileAsJSON(t, "GET", "test-fixtures/get_user.json", 2, DummyRequestBodyValidator))
+	defer server.Close()
+	user, err := client.GetUser("jdoe")
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+	assert.Equal(t, "jdoe", user.LoginName)
+	assert.Equal(t, "jdoe@example.com", user.Email)
+	assert.Equal(t, []string{"jdoe", "johndoe"}, user.CheckinAliases)
+}
+
+func TestGetUserNotFound(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/users/nobody", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Either the resource you requested was not found, or you are not authorized to perform this action."}`))
+	})
+	defer server.Close()
+	user, err := client.GetUser("nobody")
+	assert.True(t, IsNotFound(err))
+	assert.Nil(t, user)
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"login_name":"alice","enabled":true,"email":"alice@example.com","checkin_aliases":["alice"]}`
+	client, server := newTestAPIClient("/go/api/users", serveFileAsJSON(t, "POST", "test-fixtures/create_user.json", 2, jsonBodyValidator(expectedBody)))
+	defer server.Close()
+	user, err := client.CreateUser(&User{
+		LoginName:      "alice",
+		Enabled:        true,
+		Email:          "alice@example.com",
+		CheckinAliases: []string{"alice"},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+	assert.Equal(t, "alice", user.LoginName)
+	assert.Equal(t, "alice", user.DisplayName)
+}
+
+func TestCreateUserLoginOnly(t *testing.T) {
+	t.Parallel()
+	// GoCD creates an enabled user unless told otherwise
+	client, server := newTestAPIClient("/go/api/users", serveFileAsJSON(t, "POST", "test-fixtures/create_user.json", 2, jsonBodyValidator(`{"login_name":"alice"}`)))
+	defer server.Close()
+	user, err := client.CreateUser(&User{LoginName: "alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user.LoginName)
+}
+
+func TestUpdateUser(t *testing.T) {
+	t.Parallel()
+	expectedBody := `{"enabled":false,"email":"john.doe@example.com","checkin_aliases":[]}`
+	client, server := newTestAPIClient("/go/api/users/jdoe", serveFileAsJSON(t, "PATCH", "test-fixtures/patch_user.json", 2, jsonBodyValidator(expectedBody)))
+	defer server.Close()
+	user, err := client.UpdateUser("jdoe", UserUpdate{
+		Enabled:        Bool(false),
+		Email:          String("john.doe@example.com"),
+		CheckinAliases: []string{},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+	assert.False(t, user.Enabled)
+	assert.Equal(t, "john.doe@example.com", user.Email)
+}
+
+func TestUserUpdateOnlySendsGivenFields(t *testing.T) {
+	body, err := json.Marshal(UserUpdate{EmailMe: Bool(true)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"email_me":true}`, string(body))
+}
+
+func TestDeleteUsers(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient("/go/api/users", serveFileAsJSON(t, "DELETE", "test-fixtures/delete_users.json", 2, jsonBodyValidator(`{"users":["jdoe","bob"]}`)))
+	defer server.Close()
+	err := client.DeleteUsers([]string{"jdoe", "bob"})
+	assert.NoError(t, err)
+}
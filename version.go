@@ -0,0 +1,291 @@
+package gocd
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerVersion describes the version of the GoCD server
+type ServerVersion struct {
+	Version     string `json:"version"`
+	BuildNumber string `json:"build_number"`
+	GitSHA      string `json:"git_sha"`
+	FullVersion string `json:"full_version"`
+	CommitURL   string `json:"commit_url"`
+}
+
+// AtLeast reports whether the server runs the given version (e.g. "18.2.0") or
+// a later one
+func (v *ServerVersion) AtLeast(version string) bool {
+	return compareVersions(v.Version, version) >= 0
+}
+
+// compareVersions compares two dotted versions numerically, returning -1, 0 or
+// +1 like strings.Compare. Missing or non numeric parts count as 0, so that
+// "18.2" == "18.2.0".
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := versionPart(as, i), versionPart(bs, i)
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	// drop any suffix such as in "19.1.0-8469"
+	part := strings.SplitN(parts[i], "-", 2)[0]
+	n, _ := strconv.Atoi(part)
+	return n
+}
+
+// legacyServerVersion is assumed for the servers which don't expose their
+// version: /api/version was added in GoCD 16.6.0
+const legacyServerVersion = "16.5.0"
+
+// WithServerVersion skips the detection of the server version and negotiates
+// the API versions for the given one (e.g. "18.6.0") instead
+func WithServerVersion(version string) Option {
+	return func(c *DefaultClient) {
+		c.serverVersion = &ServerVersion{Version: version}
+	}
+}
+
+// GetServerVersion returns the version of the GoCD server
+func (c *DefaultClient) GetServerVersion() (*ServerVersion, error) {
+	return c.GetServerVersionContext(context.Background())
+}
+
+// GetServerVersionContext is the same as GetServerVersion, bound to the given context
+func (c *DefaultClient) GetServerVersionContext(ctx context.Context) (*ServerVersion, error) {
+	res := new(ServerVersion)
+	err := c.do(ctx, http.MethodGet, "/api/version", apiVersions[apiServerVersion][0].headers(), nil, res)
+	return res, err
+}
+
+// serverVersionRetryDelay is how long the client goes without the server
+// version after failing to detect it, rather than asking for it before every
+// request while the server is unavailable
+const serverVersionRetryDelay = 30 * time.Second
+
+// detectServerVersion returns the version of the server, asking it the first
+// time only. It returns nil when the version couldn't be found out. Concurrent
+// callers share a single detection, and a failed one isn't attempted again
+// before serverVersionRetryDelay.
+func (c *DefaultClient) detectServerVersion(ctx context.Context) *ServerVersion {
+	for {
+		c.serverVersionMu.Lock()
+		if c.serverVersion != nil || time.Now().Before(c.serverVersionRetryAt) {
+			version := c.serverVersion
+			c.serverVersionMu.Unlock()
+			return version
+		}
+		if detecting := c.serverVersionDetecting; detecting != nil {
+			c.serverVersionMu.Unlock()
+			select {
+			case <-detecting:
+				continue
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		detecting := make(chan struct{})
+		c.serverVersionDetecting = detecting
+		c.serverVersionMu.Unlock()
+
+		version, err := c.GetServerVersionContext(ctx)
+
+		c.serverVersionMu.Lock()
+		switch {
+		case IsNotFound(err):
+			c.serverVersion = &ServerVersion{Version: legacyServerVersion}
+		case err != nil || version.Version == "":
+			// try again later, the server may just be unavailable. A
+			// cancelled caller says nothing about the server though.
+			if ctx.Err() == nil {
+				c.serverVersionRetryAt = time.Now().Add(serverVersionRetryDelay)
+			}
+		default:
+			c.serverVersion = version
+		}
+		c.serverVersionDetecting = nil
+		close(detecting)
+		detected := c.serverVersion
+		c.serverVersionMu.Unlock()
+		return detected
+	}
+}
+
+// mediaType holds the headers an API endpoint expects
+type mediaType struct {
+	// since is the oldest GoCD version these headers apply to
+	since  string
+	accept string
+	// confirm is the name of the header GoCD requires to confirm actions
+	// such as pausing a pipeline, if any
+	confirm string
+}
+
+func (m mediaType) headers() map[string]string {
+	headers := map[string]string{}
+	if m.accept != "" {
+		headers["Accept"] = m.accept
+	}
+	if m.confirm != "" {
+		headers[m.confirm] = "true"
+	}
+	return headers
+}
+
+// API endpoints, as keys of apiVersions
+const (
+	apiAgents                = "agents"
+	apiAgentJobRunHistory    = "agents/job_run_history"
+	apiArtifacts             = "files"
+	apiEnvironments          = "admin/environments"
+	apiJobHistory            = "jobs/history"
+	apiMaterials             = "config/materials"
+	apiMaterialModifications = "materials/modifications"
+	apiMaterialNotify        = "material/notify"
+	apiPipelineConfig        = "admin/pipelines"
+	apiPipelineGroups        = "admin/pipeline_groups"
+	apiPipelineInstance      = "pipelines/instance"
+	apiPipelineHistory       = "pipelines/history"
+	apiPipelineStatus        = "pipelines/status"
+	apiPipelinePause         = "pipelines/pause"
+	apiPipelineUnpause       = "pipelines/unpause"
+	apiPipelineUnlock        = "pipelines/unlock"
+	apiPipelineSchedule      = "pipelines/schedule"
+	apiServerHealthMessages  = "server_health_messages"
+	apiServerVersion         = "version"
+	apiStageHistory          = "stages/history"
+	apiStageInstance         = "stages/instance"
+	apiStageOperations       = "stages/operations"
+	apiTemplates             = "admin/templates"
+	apiUsers                 = "users"
+)
+
+// apiVersions lists the headers supported by each endpoint, the most recent
+// GoCD version first. The first entry is used when the server version can't
+// be detected.
+var apiVersions = map[string][]mediaType{
+	apiAgents: {
+		{since: "18.7.0", accept: "application/vnd.go.cd.v6+json"},
+		{since: "17.12.0", accept: "application/vnd.go.cd.v5+json"},
+		{since: "17.1.0", accept: "application/vnd.go.cd.v4+json"},
+		{since: "0", accept: "application/vnd.go.cd.v2+json"},
+	},
+	apiAgentJobRunHistory: {
+		{since: "0", accept: "application/json"},
+	},
+	apiArtifacts: {
+		// the artifacts are served outside of the API, only the uploads
+		// need a confirmation
+		{since: "18.2.0", confirm: "X-GoCD-Confirm"},
+		{since: "0", confirm: "Confirm"},
+	},
+	apiEnvironments: {
+		{since: "17.2.0", accept: "application/vnd.go.cd.v2+json"},
+		{since: "0", accept: "application/vnd.go.cd.v1+json"},
+	},
+	apiJobHistory: {
+		// 18.6.0: providing API version here results in "resource not found"
+		{since: "0", accept: "application/json"},
+	},
+	apiMaterials: {
+		{since: "0", accept: "application/json"},
+	},
+	apiMaterialModifications: {
+		{since: "0", accept: "application/json"},
+	},
+	apiMaterialNotify: {
+		{since: "18.2.0", accept: "application/json", confirm: "X-GoCD-Confirm"},
+		{since: "0", accept: "application/json", confirm: "Confirm"},
+	},
+	apiPipelineConfig: {
+		{since: "18.7.0", accept: "application/vnd.go.cd.v6+json"},
+		{since: "0", accept: "application/vnd.go.cd.v5+json"},
+	},
+	apiPipelineGroups: {
+		{since: "0", accept: "application/vnd.go.cd.v1+json"},
+	},
+	apiPipelineInstance: {
+		{since: "0"},
+	},
+	apiPipelineHistory: {
+		{since: "0"},
+	},
+	apiPipelineStatus: {
+		{since: "0"},
+	},
+	apiPipelinePause: {
+		{since: "18.2.0", accept: "application/vnd.go.cd.v1+json", confirm: "X-GoCD-Confirm"},
+		{since: "0", accept: "application/json", confirm: "Confirm"},
+	},
+	apiPipelineUnpause: {
+		{since: "18.2.0", accept: "application/vnd.go.cd.v1+json", confirm: "X-GoCD-Confirm"},
+		{since: "0", accept: "application/json", confirm: "Confirm"},
+	},
+	apiPipelineUnlock: {
+		{since: "18.2.0", accept: "application/vnd.go.cd.v1+json", confirm: "X-GoCD-Confirm"},
+	},
+	apiPipelineSchedule: {
+		{since: "18.2.0", accept: "application/vnd.go.cd.v1+json", confirm: "X-GoCD-Confirm"},
+	},
+	apiServerHealthMessages: {
+		{since: "0", accept: "application/vnd.go.cd.v1+json"},
+	},
+	apiServerVersion: {
+		{since: "0", accept: "application/vnd.go.cd.v1+json"},
+	},
+	apiStageHistory: {
+		{since: "0", accept: "application/json"},
+	},
+	apiStageInstance: {
+		{since: "0", accept: "application/json"},
+	},
+	apiStageOperations: {
+		{since: "20.1.0", accept: "application/vnd.go.cd.v2+json", confirm: "X-GoCD-Confirm"},
+		{since: "0", accept: "application/vnd.go.cd.v1+json", confirm: "X-GoCD-Confirm"},
+	},
+	apiTemplates: {
+		{since: "18.7.0", accept: "application/vnd.go.cd.v4+json"},
+		{since: "0", accept: "application/vnd.go.cd.v3+json"},
+	},
+	apiUsers: {
+		{since: "19.1.0", accept: "application/vnd.go.cd.v3+json"},
+		{since: "0", accept: "application/vnd.go.cd.v2+json"},
+	},
+}
+
+// negotiate picks the headers to call the given endpoint with, according to
+// the version of the server. The server version is only detected for the
+// endpoints whose headers changed over time.
+func (c *DefaultClient) negotiate(ctx context.Context, endpoint string) mediaType {
+	versions := apiVersions[endpoint]
+	if len(versions) == 1 {
+		return versions[0]
+	}
+	server := c.detectServerVersion(ctx)
+	if server == nil {
+		return versions[0]
+	}
+	for _, v := range versions {
+		if server.AtLeast(v.since) {
+			return v
+		}
+	}
+	// the server is older than any version we know of, try our luck with the oldest
+	return versions[len(versions)-1]
+}
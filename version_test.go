@@ -0,0 +1,127 @@
+package gocd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServerVersion(t *testing.T) {
+	t.Parallel()
+	client, server := newTestAPIClient(testVersionRoute, serveFileAsJSON(t, "GET", "test-fixtures/get_server_version.json", 1, DummyRequestBodyValidator))
+	defer server.Close()
+
+	version, err := client.GetServerVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "18.10.0", version.Version)
+	assert.Equal(t, "7703", version.BuildNumber)
+	assert.Equal(t, "2f6f01d6c2b1fd0af8ab20e3ea2d3d1e1ae0fa2c", version.GitSHA)
+	assert.Equal(t, "18.10.0 (7703-2f6f01d6c2b1fd0af8ab20e3ea2d3d1e1ae0fa2c)", version.FullVersion)
+	assert.True(t, version.AtLeast("18.2.0"))
+	assert.True(t, version.AtLeast("18.10"))
+	assert.False(t, version.AtLeast("18.11.0"))
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("18.2", "18.2.0"))
+	assert.Equal(t, -1, compareVersions("18.2.0", "18.10.0"))
+	assert.Equal(t, 1, compareVersions("19.1.0-8469", "19.0.0"))
+	assert.Equal(t, 1, compareVersions("16.6.0", "0"))
+}
+
+// newVersionedServer serves the given GoCD version (or a 404 when empty) and
+// records the headers the pause endpoint is called with
+func newVersionedServer(version string, detections *int32, headers *http.Header) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(detections, 1)
+		if version == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"version": "` + version + `"}`))
+	})
+	mux.HandleFunc("/go/api/pipelines/pipeline1/pause", func(w http.ResponseWriter, r *http.Request) {
+		*headers = r.Header
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestNegotiatePausePipeline(t *testing.T) {
+	t.Parallel()
+	tc := map[string]struct{ accept, confirm string }{
+		"18.6.0":  {"application/vnd.go.cd.v1+json", "X-Gocd-Confirm"},
+		"18.2.0":  {"application/vnd.go.cd.v1+json", "X-Gocd-Confirm"},
+		"17.12.0": {"application/json", "Confirm"},
+		"":        {"application/json", "Confirm"},
+	}
+	for version, expected := range tc {
+		var detections int32
+		var headers http.Header
+		server := newVersionedServer(version, &detections, &headers)
+		client := New(server.URL, testUsername, testPassword)
+		for i := 0; i < 2; i++ {
+			_, err := client.PausePipeline("pipeline1", "maintenance")
+			assert.NoError(t, err)
+			assert.Equal(t, expected.accept, headers.Get("Accept"), version)
+			assert.Equal(t, "true", headers.Get(expected.confirm), version)
+		}
+		assert.Equal(t, int32(1), detections, "the version should be detected once")
+		server.Close()
+	}
+}
+
+func TestWithServerVersion(t *testing.T) {
+	t.Parallel()
+	var detections int32
+	var headers http.Header
+	server := newVersionedServer("18.6.0", &detections, &headers)
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithServerVersion("17.4.0"))
+	_, err := client.PausePipeline("pipeline1", "maintenance")
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", headers.Get("Accept"))
+	assert.Equal(t, int32(0), detections)
+}
+
+func TestServerVersionDetectionFailure(t *testing.T) {
+	t.Parallel()
+	var detections, pauses int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(testVersionRoute, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&detections, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/go/api/pipelines/pipeline1/pause", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pauses, 1)
+		// the most recent headers are used without the version
+		assert.Equal(t, "application/vnd.go.cd.v1+json", r.Header.Get("Accept"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := New(server.URL, testUsername, testPassword)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.PausePipeline("pipeline1", "maintenance")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	_, err := client.PausePipeline("pipeline1", "maintenance")
+	assert.NoError(t, err)
+	// the concurrent calls shared the failed detection, which isn't
+	// attempted again right away
+	assert.Equal(t, int32(1), detections)
+	assert.Equal(t, int32(6), pauses)
+}
@@ -0,0 +1,201 @@
+package gocd
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Results of a completed pipeline run, as returned by WaitForPipeline
+const (
+	PipelinePassed    = "Passed"
+	PipelineFailed    = "Failed"
+	PipelineCancelled = "Cancelled"
+)
+
+// defaultNotFoundTimeout is how long a pipeline run may not exist, by default,
+// when waiting for it
+const defaultNotFoundTimeout = 2 * time.Minute
+
+// ErrWaitTimeout is returned by WaitForPipeline when WaitOptions.Timeout
+// elapses before the pipeline run completes
+var ErrWaitTimeout = errors.New("gocd: timed out waiting for the pipeline run to complete")
+
+// WaitOptions customizes how WaitForPipeline polls GoCD
+type WaitOptions struct {
+	// MinInterval is the delay between the first polls, 2s when zero. It
+	// doubles whenever nothing changed, up to MaxInterval (30s when zero), and
+	// is reset as soon as a stage or a job moves on.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// Timeout bounds the wait, when not zero
+	Timeout time.Duration
+	// NotFoundTimeout is how long the run may not exist before the wait gives
+	// up with the 404 error of GoCD, 2m when zero. It guards against a
+	// misspelled pipeline name or a counter that will never be reached.
+	NotFoundTimeout time.Duration
+	// OnTransition, when not nil, is called for every change of the state of a
+	// stage or job of the pipeline run
+	OnTransition func(Transition)
+}
+
+// Transition is a change of the state of a stage or job observed by
+// WaitForPipeline
+type Transition struct {
+	Stage        string
+	StageCounter string
+	// Job is empty for the transitions of the stage itself
+	Job string
+	// From is the previous state, empty when the stage or job is first seen
+	From string
+	// To is the new state, e.g. "Building" for a job. The state of a stage is
+	// its result, "Unknown" until it completes.
+	To     string
+	Result string
+}
+
+// PipelineResult is the outcome of a pipeline run
+type PipelineResult struct {
+	// Result is PipelinePassed, PipelineFailed or PipelineCancelled, or empty
+	// when the run isn't completed
+	Result   string
+	Instance *PipelineInstance
+}
+
+// WaitForPipeline polls the given run of a pipeline until it completes, i.e.
+// until all its stages passed or one of them failed or was cancelled. A run
+// stopping before a stage requiring a manual approval is considered completed.
+// The run doesn't have to exist yet, e.g. right after SchedulePipeline, but
+// GoCD has to create it within WaitOptions.NotFoundTimeout.
+//
+// When the wait is interrupted by WaitOptions.Timeout (ErrWaitTimeout) or by
+// ctx, the last state of the run seen, if any, is returned along with the
+// error.
+func (c *DefaultClient) WaitForPipeline(ctx context.Context, name string, counter int, opts WaitOptions) (*PipelineResult, error) {
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	minInterval, maxInterval := opts.MinInterval, opts.MaxInterval
+	if minInterval <= 0 {
+		minInterval = 2 * time.Second
+	}
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	notFoundTimeout := opts.NotFoundTimeout
+	if notFoundTimeout <= 0 {
+		notFoundTimeout = defaultNotFoundTimeout
+	}
+
+	res := new(PipelineResult)
+	states := map[string]string{}
+	interval := minInterval
+	var notFoundSince time.Time
+	for {
+		instance, err := c.GetPipelineInstanceContext(waitCtx, name, counter)
+		switch {
+		case err == nil:
+			notFoundSince = time.Time{}
+			res.Instance = instance
+			if observeTransitions(instance, states, opts.OnTransition) {
+				interval = minInterval
+			}
+			if res.Result = pipelineResult(instance); res.Result != "" {
+				return res, nil
+			}
+		case IsNotFound(err):
+			// GoCD didn't create the run yet
+			if notFoundSince.IsZero() {
+				notFoundSince = time.Now()
+			} else if time.Since(notFoundSince) >= notFoundTimeout {
+				return res, err
+			}
+		default:
+			return res, timeoutError(ctx, waitCtx, err, ErrWaitTimeout)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return res, timeoutError(ctx, waitCtx, waitCtx.Err(), ErrWaitTimeout)
+		case <-timer.C:
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// timeoutError reports the expiration of the timeout of boundCtx, derived from
+// ctx, as timeoutErr rather than as a deadline of ctx
+func timeoutError(ctx, boundCtx context.Context, err, timeoutErr error) error {
+	if ctx.Err() == nil && boundCtx.Err() == context.DeadlineExceeded {
+		return timeoutErr
+	}
+	return err
+}
+
+// observeTransitions compares the states of the stages and jobs of the
+// instance with the previous ones, recorded in states, and reports the
+// changes to onTransition. It returns whether anything changed.
+func observeTransitions(instance *PipelineInstance, states map[string]string, onTransition func(Transition)) bool {
+	changed := false
+	observe := func(t Transition) {
+		key := t.Stage + "/" + t.StageCounter + "/" + t.Job
+		if t.From = states[key]; t.From == t.To {
+			return
+		}
+		states[key] = t.To
+		changed = true
+		if onTransition != nil {
+			onTransition(t)
+		}
+	}
+
+	for _, stage := range instance.Stages {
+		if !stage.Scheduled {
+			continue
+		}
+		for _, job := range stage.Jobs {
+			observe(Transition{Stage: stage.Name, StageCounter: stage.Counter, Job: job.Name, To: job.State, Result: job.Result})
+		}
+		observe(Transition{Stage: stage.Name, StageCounter: stage.Counter, To: stage.Result, Result: stage.Result})
+	}
+	return changed
+}
+
+// pipelineResult returns the result of the run, or "" if it isn't completed.
+// A run none of whose stages is scheduled yet, as GoCD briefly shows a run it
+// just created, isn't.
+func pipelineResult(instance *PipelineInstance) string {
+	if len(instance.Stages) == 0 || !instance.Stages[0].Scheduled {
+		return ""
+	}
+	for _, stage := range instance.Stages {
+		if !stage.Scheduled {
+			if stage.ApprovalType == "manual" {
+				// the run won't go any further on its own
+				break
+			}
+			// GoCD is about to schedule the stage
+			return ""
+		}
+		for _, job := range stage.Jobs {
+			if job.State != "Completed" {
+				return ""
+			}
+		}
+		switch stage.Result {
+		case PipelineFailed, PipelineCancelled:
+			return stage.Result
+		case PipelinePassed:
+		default:
+			return ""
+		}
+	}
+	return PipelinePassed
+}
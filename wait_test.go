@@ -0,0 +1,165 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testWaitOptions = WaitOptions{MinInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+// newTestRunServer serves the successive states of the run #3 of pipeline1,
+// the last one over and over, after answering 404 for the first notYet polls
+func newTestRunServer(t *testing.T, notYet int32, runs ...PipelineInstance) (Client, func()) {
+	var polls int32
+	client, server := newTestAPIClient("/go/api/pipelines/pipeline1/instance/3", func(w http.ResponseWriter, r *http.Request) {
+		poll := atomic.AddInt32(&polls, 1) - 1 - notYet
+		if poll < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if int(poll) >= len(runs) {
+			poll = int32(len(runs) - 1)
+		}
+		json.NewEncoder(w).Encode(runs[poll])
+	})
+	return client, server.Close
+}
+
+func testRun(stages ...StageRun) PipelineInstance {
+	return PipelineInstance{Name: "pipeline1", Counter: 3, Stages: stages}
+}
+
+func testStage(name, result string, scheduled bool, jobStates ...string) StageRun {
+	stage := StageRun{Name: name, Counter: "1", Result: result, Scheduled: scheduled, ApprovalType: "success"}
+	for _, state := range jobStates {
+		jobResult := "Unknown"
+		if state == "Completed" {
+			jobResult = result
+		}
+		stage.Jobs = append(stage.Jobs, Job{Name: "job1", State: state, Result: jobResult})
+	}
+	return stage
+}
+
+func TestWaitForPipeline(t *testing.T) {
+	t.Parallel()
+	client, stop := newTestRunServer(t, 2,
+		testRun(testStage("build", "Unknown", true, "Scheduled"), testStage("deploy", "Unknown", false)),
+		testRun(testStage("build", "Unknown", true, "Building"), testStage("deploy", "Unknown", false)),
+		testRun(testStage("build", "Passed", true, "Completed"), testStage("deploy", "Unknown", false)),
+		testRun(testStage("build", "Passed", true, "Completed"), testStage("deploy", "Unknown", true, "Building")),
+		testRun(testStage("build", "Passed", true, "Completed"), testStage("deploy", "Passed", true, "Completed")),
+	)
+	defer stop()
+
+	var transitions []Transition
+	opts := testWaitOptions
+	opts.OnTransition = func(t Transition) {
+		transitions = append(transitions, t)
+	}
+	res, err := client.WaitForPipeline(context.Background(), "pipeline1", 3, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, PipelinePassed, res.Result)
+	assert.Equal(t, 3, res.Instance.Counter)
+	assert.Equal(t, []Transition{
+		{Stage: "build", StageCounter: "1", Job: "job1", To: "Scheduled", Result: "Unknown"},
+		{Stage: "build", StageCounter: "1", To: "Unknown", Result: "Unknown"},
+		{Stage: "build", StageCounter: "1", Job: "job1", From: "Scheduled", To: "Building", Result: "Unknown"},
+		{Stage: "build", StageCounter: "1", Job: "job1", From: "Building", To: "Completed", Result: "Passed"},
+		{Stage: "build", StageCounter: "1", From: "Unknown", To: "Passed", Result: "Passed"},
+		{Stage: "deploy", StageCounter: "1", Job: "job1", To: "Building", Result: "Unknown"},
+		{Stage: "deploy", StageCounter: "1", To: "Unknown", Result: "Unknown"},
+		{Stage: "deploy", StageCounter: "1", Job: "job1", From: "Building", To: "Completed", Result: "Passed"},
+		{Stage: "deploy", StageCounter: "1", From: "Unknown", To: "Passed", Result: "Passed"},
+	}, transitions)
+}
+
+func TestWaitForPipelineFailed(t *testing.T) {
+	t.Parallel()
+	client, stop := newTestRunServer(t, 0,
+		testRun(testStage("build", "Unknown", true, "Building", "Completed"), testStage("deploy", "Unknown", false)),
+		testRun(testStage("build", "Failed", true, "Completed", "Completed"), testStage("deploy", "Unknown", false)),
+	)
+	defer stop()
+
+	res, err := client.WaitForPipeline(context.Background(), "pipeline1", 3, testWaitOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineFailed, res.Result)
+}
+
+func TestWaitForPipelineManualApproval(t *testing.T) {
+	t.Parallel()
+	deploy := testStage("deploy", "Unknown", false)
+	deploy.ApprovalType = "manual"
+	client, stop := newTestRunServer(t, 0, testRun(testStage("build", "Passed", true, "Completed"), deploy))
+	defer stop()
+
+	res, err := client.WaitForPipeline(context.Background(), "pipeline1", 3, testWaitOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, PipelinePassed, res.Result)
+}
+
+func TestWaitForPipelineNotStarted(t *testing.T) {
+	t.Parallel()
+	build := testStage("build", "Unknown", false)
+	build.ApprovalType = "manual"
+	client, stop := newTestRunServer(t, 0,
+		testRun(),
+		testRun(build),
+		testRun(testStage("build", "Passed", true, "Completed")),
+	)
+	defer stop()
+
+	res, err := client.WaitForPipeline(context.Background(), "pipeline1", 3, testWaitOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, PipelinePassed, res.Result)
+	// the run wasn't reported as passed before it even started
+	assert.True(t, res.Instance.Stages[0].Scheduled)
+}
+
+func TestWaitForPipelineTimeout(t *testing.T) {
+	t.Parallel()
+	client, stop := newTestRunServer(t, 0, testRun(testStage("build", "Unknown", true, "Building")))
+	defer stop()
+
+	opts := testWaitOptions
+	opts.Timeout = 50 * time.Millisecond
+	res, err := client.WaitForPipeline(context.Background(), "pipeline1", 3, opts)
+	assert.Equal(t, ErrWaitTimeout, err)
+	assert.Equal(t, "", res.Result)
+	assert.Equal(t, "Building", res.Instance.Stages[0].Jobs[0].State)
+}
+
+func TestWaitForPipelineCanceled(t *testing.T) {
+	t.Parallel()
+	client, stop := newTestRunServer(t, 1000)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	res, err := client.WaitForPipeline(ctx, "pipeline1", 3, testWaitOptions)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Nil(t, res.Instance)
+}
+
+func TestWaitForPipelineNotFound(t *testing.T) {
+	t.Parallel()
+	client, stop := newTestRunServer(t, 1000)
+	defer stop()
+
+	opts := testWaitOptions
+	opts.NotFoundTimeout = 20 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	res, err := client.WaitForPipeline(ctx, "pipeline1", 3, opts)
+	assert.True(t, IsNotFound(err), "unexpected error %v", err)
+	assert.NoError(t, ctx.Err())
+	assert.Nil(t, res.Instance)
+}